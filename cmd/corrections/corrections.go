@@ -0,0 +1,52 @@
+package main
+
+// Lists the corrections which apply to the chain in the database.
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.com/thorchain/midgard/config"
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+)
+
+func main() {
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05", FullTimestamp: true})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	if len(os.Args) != 2 {
+		logrus.Fatalf("Provide 1 argument, %d provided\nUsage: $ corrections config",
+			len(os.Args)-1)
+	}
+
+	var c config.Config = config.ReadConfigFrom(os.Args[1])
+	record.CorrectionsFile = c.CorrectionsFile
+	corrections, err := record.ReadCorrections()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	db.Setup(&c.TimeScale)
+	db.LoadFirstBlockFromDB(context.Background())
+	chainID := db.ChainID()
+	if chainID == "" {
+		logrus.Fatal("No chain ID yet, the database has no blocks")
+	}
+
+	source := "embedded"
+	if c.CorrectionsFile != "" {
+		source = c.CorrectionsFile
+	}
+	list := corrections.For(chainID)
+	logrus.Infof("%d corrections from %s apply to chain %s", len(list), source, chainID)
+	for _, correction := range list {
+		if correction.Add != nil {
+			logrus.Infof("Height %d adds %s: %s", correction.Height, correction.Add.Type, correction.Description)
+		}
+		if correction.Override != nil {
+			logrus.Infof("Height %d overrides %s: %s", correction.Height, correction.Override.Type, correction.Description)
+		}
+	}
+}
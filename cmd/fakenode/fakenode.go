@@ -0,0 +1,83 @@
+package main
+
+// Serves blocks with the Tendermint RPC, for running Midgard end to end without THORNode.
+// Point thorchain.tendermint_url to http://localhost:26657/websocket.
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.com/thorchain/midgard/internal/fetch/archive"
+	"gitlab.com/thorchain/midgard/internal/fetch/chain"
+	"gitlab.com/thorchain/midgard/internal/fetch/fakenode"
+)
+
+func main() {
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05", FullTimestamp: true})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	listen := flag.String("listen", ":26657", "HTTP listen address")
+	chainID := flag.String("chain-id", "fakechain", "network reported in status")
+	archiveDir := flag.String("archive", "", "serve the blocks of a Midgard block archive directory")
+	interval := flag.Duration("interval", 0, "add the blocks one at a time with this interval, instead of all at once")
+	flag.Usage = func() {
+		logrus.Infof("Usage: $ fakenode [flags] [fixtures.json ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var blocks []chain.Block
+	if *archiveDir != "" {
+		blocks = readArchive(*archiveDir)
+	}
+	for _, path := range flag.Args() {
+		fixtures, err := fakenode.ReadFixtures(path)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		blocks = append(blocks, fixtures...)
+	}
+	if len(blocks) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	node := fakenode.New(*chainID)
+	if *interval == 0 {
+		if err := node.Add(blocks...); err != nil {
+			logrus.Fatal(err)
+		}
+	} else {
+		go func() {
+			if err := node.ReleaseEvery(context.Background(), *interval, blocks); err != nil {
+				logrus.Fatal(err)
+			}
+			logrus.Info("All blocks added")
+		}()
+	}
+
+	logrus.Infof("Serving %d blocks of chain %q on %s", len(blocks), *chainID, *listen)
+	logrus.Fatal(http.ListenAndServe(*listen, node.Handler()))
+}
+
+func readArchive(dir string) (blocks []chain.Block) {
+	ch := make(chan chain.Block, 99)
+	done := make(chan struct{})
+	go func() {
+		for block := range ch {
+			blocks = append(blocks, block)
+		}
+		close(done)
+	}()
+	height, err := archive.NewSource(dir).CatchUp(context.Background(), ch, 1)
+	close(ch)
+	<-done
+	if err != chain.ErrNoData {
+		logrus.Fatal("Archive read failed: ", err)
+	}
+	logrus.Infof("Read blocks 1–%d from archive %q", height-1, dir)
+	return blocks
+}
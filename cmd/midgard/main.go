@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"gitlab.com/thorchain/midgard/config"
 	"gitlab.com/thorchain/midgard/internal/api"
 	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/fetch/archive"
 	"gitlab.com/thorchain/midgard/internal/fetch/chain"
 	"gitlab.com/thorchain/midgard/internal/fetch/notinchain"
 	"gitlab.com/thorchain/midgard/internal/fetch/record"
@@ -49,22 +51,34 @@ func main() {
 
 	stat.SetUsdPools(c.UsdPools)
 
+	record.CorrectionsFile = c.CorrectionsFile
+	// fail early, corrections load once the chain ID is known
+	if _, err := record.ReadCorrections(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to read corrections")
+	}
+
+	db.LockWriter(&c.TimeScale)
 	db.Setup(&c.TimeScale)
 
 	mainContext, mainCancel := context.WithCancel(context.Background())
 
-	blocks, fetchJob := startBlockFetch(mainContext, &c)
+	// before the fetch, which may replay the archive
+	archiveWriter := openArchive(&c)
+
+	blocks, client, fetchJob := startBlockFetch(mainContext, &c)
 
 	httpServerJob := startHTTPServer(mainContext, &c)
 
 	websocketsJob := startWebsockets(mainContext, &c)
 
-	blockWriteJob := startBlockWrite(mainContext, &c, blocks)
+	blockWriteJob := startBlockWrite(mainContext, &c, blocks, client, archiveWriter)
 
 	cacheJob := api.GlobalCacheStore.StartBackgroundRefresh(mainContext)
 
 	aggregatesRefresJob := db.StartAggregatesRefresh(mainContext)
 
+	replicaHealthJob := db.StartReplicaHealthCheck(mainContext)
+
 	signal := <-signals
 	timeout := c.ShutdownTimeout.WithDefault(5 * time.Second)
 	log.Info().Msgf("Shutting down services initiated with timeout in %s", timeout)
@@ -79,6 +93,7 @@ func main() {
 		blockWriteJob,
 		cacheJob,
 		aggregatesRefresJob,
+		replicaHealthJob,
 	)
 
 	log.Fatal().Msgf("Exit on signal %s", signal)
@@ -99,7 +114,7 @@ func startWebsockets(ctx context.Context, c *config.Config) *jobs.Job {
 
 // startBlockFetch launches the synchronisation routine.
 // Stops fetching when ctx is cancelled.
-func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block, *jobs.Job) {
+func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block, *chain.Client, *jobs.Job) {
 	notinchain.BaseURL = c.ThorChain.ThorNodeURL
 
 	// instantiate client
@@ -120,7 +135,12 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 	log.Info().Msgf("Starting with previous blockchain height %d", lastFetchedHeight)
 
 	var lastNoData atomic.Value
+	var fetchedHeight int64
 	api.InSync = func() bool {
+		if client.Subscribed() {
+			// The block announced last may still be in flight.
+			return client.AnnouncedHeight() <= atomic.LoadInt64(&fetchedHeight)+1
+		}
 		lastTime, ok := lastNoData.Load().(time.Time)
 		if !ok {
 			// first node didn't load yet.
@@ -129,6 +149,10 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 		return time.Since(lastTime) < 2*c.ThorChain.LastChainBackoff.WithDefault(7*time.Second)
 	}
 
+	// new blocks are pushed by the node, with polling as a fallback
+	newBlocks := make(chan struct{}, 1)
+	go client.Subscribe(ctx, newBlocks)
+
 	// launch read routine
 	ch := make(chan chain.Block, 99)
 	job := jobs.Start("BlockFetch", func() {
@@ -136,6 +160,13 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 		backoff := time.NewTicker(c.ThorChain.LastChainBackoff.WithDefault(7 * time.Second))
 		defer backoff.Stop()
 
+		if c.Archive.Replay {
+			nextHeightToFetch, err = replayArchive(ctx, c.Archive.Dir, ch, nextHeightToFetch)
+			if err != nil {
+				log.Error().Err(err).Msg("Block archive replay failed, continuing from Tendermint")
+			}
+		}
+
 		// TODO(pascaldekloe): Could use a limited number of
 		// retries with skip block logic perhaps?
 		for {
@@ -143,6 +174,7 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 				return
 			}
 			nextHeightToFetch, err = client.CatchUp(ctx, ch, nextHeightToFetch)
+			atomic.StoreInt64(&fetchedHeight, nextHeightToFetch-1)
 			switch err {
 			case chain.ErrNoData:
 				db.SetInSync(true)
@@ -151,6 +183,8 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 				log.Info().Err(err).Msgf("Block fetch error, retrying")
 			}
 			select {
+			case <-newBlocks:
+				// Noop
 			case <-backoff.C:
 				// Noop
 			case <-ctx.Done():
@@ -159,7 +193,22 @@ func startBlockFetch(ctx context.Context, c *config.Config) (<-chan chain.Block,
 		}
 	})
 
-	return ch, &job
+	return ch, client, &job
+}
+
+// replayArchive sends the blocks in the archive from nextHeight on, and returns the height
+// to continue from.
+func replayArchive(ctx context.Context, dir string, ch chan<- chain.Block, nextHeight int64) (int64, error) {
+	if dir == "" {
+		return nextHeight, errors.New("replay needs archive dir configured")
+	}
+	log.Info().Msgf("Replaying block archive %q from height %d", dir, nextHeight)
+	height, err := archive.NewSource(dir).CatchUp(ctx, ch, nextHeight)
+	if err != chain.ErrNoData {
+		return height, err
+	}
+	log.Info().Msgf("Replayed block archive up to height %d", height-1)
+	return height, nil
 }
 
 func startHTTPServer(ctx context.Context, c *config.Config) *jobs.Job {
@@ -191,7 +240,21 @@ func startHTTPServer(ctx context.Context, c *config.Config) *jobs.Job {
 	return &ret
 }
 
-func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.Block) *jobs.Job {
+// openArchive returns nil when the block archive is not enabled.
+func openArchive(c *config.Config) *archive.Writer {
+	if c.Archive.Dir == "" {
+		return nil
+	}
+	ret, err := archive.NewWriter(c.Archive.Dir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to open block archive")
+	}
+	log.Info().Msgf("Archiving blocks in %q, continuing at height %d", c.Archive.Dir, ret.NextHeight())
+	return ret
+}
+
+func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.Block, client *chain.Client,
+	archiveWriter *archive.Writer) *jobs.Job {
 	db.LoadFirstBlockFromDB(context.Background())
 	record.LoadCorrections(db.ChainID())
 
@@ -202,6 +265,13 @@ func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.
 	var lastHeightWritten int64
 
 	ret := jobs.Start("BlockWrite", func() {
+		if archiveWriter != nil {
+			defer func() {
+				if err := archiveWriter.Close(); err != nil {
+					log.Error().Err(err).Msg("Failed to close block archive")
+				}
+			}()
+		}
 		m := record.Demux{}
 
 		var err error
@@ -222,7 +292,20 @@ func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.
 					log.Error().Msg("Block height of 0 is invalid")
 					break loop
 				}
+				err = timeseries.VerifyParent(block.Height, block.ParentHash)
+				if err != nil {
+					var divergence *timeseries.ChainDivergenceError
+					if errors.As(err, &divergence) && c.ThorChain.TrimOnDivergence {
+						trimDivergence(ctx, client, divergence)
+					}
+					break loop
+				}
+
 				t := writeTimer.One()
+				err = db.EnsurePartitions(ctx, block.Time)
+				if err != nil {
+					break loop
+				}
 				err = db.Begin()
 				if err != nil {
 					break loop
@@ -241,6 +324,13 @@ func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.
 				}
 				lastHeightWritten = block.Height
 				t()
+
+				if archiveWriter != nil {
+					// Blocks missed are no problem; a gap starts a new segment.
+					if err := archiveWriter.Write(block); err != nil {
+						log.Error().Err(err).Msgf("Failed to archive block %d", block.Height)
+					}
+				}
 			}
 		}
 		log.Error().Err(err).Msg("Unrecoverable error in BlockWriter, terminating")
@@ -248,3 +338,28 @@ func startBlockWrite(ctx context.Context, c *config.Config, blocks <-chan chain.
 	})
 	return &ret
 }
+
+// TrimDivergence deletes the blocks which are not on the chain of the Tendermint node.
+// Midgard needs a restart afterwards to rebuild its in-memory state.
+func trimDivergence(ctx context.Context, client *chain.Client, divergence *timeseries.ChainDivergenceError) {
+	log.Error().Err(divergence).Msg("Looking for the last height consistent with the Tendermint node")
+	consistentHeight, err := timeseries.LastConsistentHeight(ctx, divergence.Height-1, client.BlockHashes)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find the last consistent height, not trimming")
+		return
+	}
+	height, timestamp, err := api.TimestampAndHeight(ctx, consistentHeight+1)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to look up height %d, not trimming", consistentHeight+1)
+		return
+	}
+	if err := db.DropAggregates(); err != nil {
+		log.Error().Err(err).Msg("Failed to drop aggregates, not trimming")
+		return
+	}
+	if err := db.DeleteBlocksFrom(ctx, height, timestamp); err != nil {
+		log.Error().Err(err).Msg("Trimming diverged blocks failed")
+		return
+	}
+	log.Warn().Msgf("Trimmed database back to height %d, restart to continue from there", consistentHeight)
+}
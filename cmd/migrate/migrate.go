@@ -0,0 +1,53 @@
+package main
+
+// Shows the schema migrations of the database, or applies them without starting Midgard.
+//
+//	migrate config status
+//	migrate config up
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.com/thorchain/midgard/config"
+	"gitlab.com/thorchain/midgard/internal/db"
+)
+
+func main() {
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05", FullTimestamp: true})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	if len(os.Args) != 3 || (os.Args[2] != "status" && os.Args[2] != "up") {
+		logrus.Fatalf("Provide 2 arguments, %d provided\nUsage: $ migrate config status|up",
+			len(os.Args)-1)
+	}
+
+	var c config.Config = config.ReadConfigFrom(os.Args[1])
+	dbObj := db.Open(&c.TimeScale)
+
+	if os.Args[2] == "up" {
+		db.LockWriter(&c.TimeScale)
+		db.UpdateDDLsIfNeeded(dbObj)
+	}
+
+	version, err := db.SchemaVersion(context.Background(), dbObj)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Infof("Schema version %d, latest migration %d", version, db.LatestVersion())
+
+	pending := db.PendingMigrations(version)
+	if len(pending) == 0 {
+		logrus.Info("No pending migrations")
+		return
+	}
+	for _, m := range pending {
+		if m.Destructive {
+			logrus.Warnf("Pending migration %d: %s (destructive, the chain is synced again)",
+				m.Version, m.Description)
+		} else {
+			logrus.Infof("Pending migration %d: %s", m.Version, m.Description)
+		}
+	}
+}
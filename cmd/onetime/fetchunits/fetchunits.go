@@ -23,7 +23,6 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -31,6 +30,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gitlab.com/thorchain/midgard/config"
 	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
 )
 
 func main() {
@@ -96,24 +96,48 @@ func main() {
 	}
 	logrus.Info("correct withdraws: ", correctWithdraws)
 	logrus.Info("adds: ", adds)
-	withdrawString := "var withdrawUnitCorrections = map[int64]withdrawUnitCorrection{\n"
-
+	// in the format of the corrections data, see record.Corrections
+	var corrections []record.Correction
 	for _, k := range sortedWithdrawKeys {
 		v := correctWithdraws[k]
-		withdrawString += fmt.Sprintf(
-			"\t%d: {\"%s\", %d},\n", k, v.TX, v.ActualUnits)
+		corrections = append(corrections, record.Correction{
+			Height:      k,
+			Description: "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+			Override: &record.CorrectionEvent{
+				Type:   "Unstake",
+				Match:  map[string]json.RawMessage{"Tx": mustJSON(v.TX)},
+				Fields: map[string]json.RawMessage{"StakeUnits": mustJSON(v.ActualUnits)},
+			},
+		})
 	}
-	withdrawString += "}\n"
-	logrus.Warn("Correct withdraws:\n", withdrawString)
-
-	addString := "var addInsteadWithdrawMap = map[int64]addInsteadWithdraw{\n"
 	for _, k := range sortedAddKeys {
 		v := adds[k]
-		addString += fmt.Sprintf(
-			"\t%d: {\"%s\", \"%s\", %d},\n", k, v.Pool, v.RuneAddr, v.Units)
+		corrections = append(corrections, record.Correction{
+			Height:      k,
+			Description: "Pool units of the member went up on withdraw, https://gitlab.com/thorchain/thornode/-/issues/896",
+			Add: &record.CorrectionEvent{
+				Type: "Stake",
+				Fields: map[string]json.RawMessage{
+					"Pool":       mustJSON(v.Pool),
+					"RuneAddr":   mustJSON(v.RuneAddr),
+					"StakeUnits": mustJSON(v.Units),
+				},
+			},
+		})
+	}
+	out, err := json.MarshalIndent(corrections, "", "\t")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Warn("Corrections:\n", string(out))
+}
+
+func mustJSON(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		logrus.Fatal(err)
 	}
-	addString += "}\n"
-	logrus.Warn("Additional adds:\n", addString)
+	return b
 }
 
 type UnitsSummary struct {
@@ -136,10 +160,10 @@ func withdrawsWithImpermanentLoss(ctx context.Context) []UnitsSummary {
 			x.stake_units,
 			x.from_addr,
 			x.block_timestamp,
-			b.height
-		FROM unstake_events AS x JOIN block_log AS b ON x.block_timestamp = b.timestamp
+			x.block_height
+		FROM unstake_events AS x
 		WHERE imp_loss_protection_e8 <> 0
-		ORDER BY height
+		ORDER BY block_height, event_index
 	`
 	rows, err := db.Query(ctx, q)
 	if err != nil {
@@ -0,0 +1,81 @@
+package main
+
+// Records the events in the quarantine again, e.g. after parser support for them was added.
+// Events which succeed are removed from the quarantine. Fails while Midgard is running, see
+// db.LockWriter.
+//
+// The in-memory state of Midgard is not part of this. When reprocessed events change pool
+// depths, trim the database to the first height printed instead, and let Midgard resync.
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.com/thorchain/midgard/config"
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+)
+
+// Events per transaction.
+const batchSize = 1000
+
+func main() {
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05", FullTimestamp: true})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	if len(os.Args) != 2 && len(os.Args) != 3 {
+		logrus.Fatalf("Provide 1 or 2 arguments, %d provided\nUsage: $ reprocess config [eventType]",
+			len(os.Args)-1)
+	}
+
+	var c config.Config = config.ReadConfigFrom(os.Args[1])
+	var eventType string
+	if len(os.Args) == 3 {
+		eventType = os.Args[2]
+	}
+
+	db.LockWriter(&c.TimeScale)
+	db.Setup(&c.TimeScale)
+	record.CorrectionsFile = c.CorrectionsFile
+	db.LoadFirstBlockFromDB(context.Background())
+	record.LoadCorrections(db.ChainID())
+
+	events, err := record.QuarantinedEvents(context.Background(), eventType, 1<<31-1)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	var demux record.Demux
+	var processed, failed int
+	var firstHeight int64
+	for i := 0; i < len(events); i += batchSize {
+		batch := events[i:]
+		if batchSize < len(batch) {
+			batch = batch[:batchSize]
+		}
+		if err := db.Begin(); err != nil {
+			logrus.Fatal(err)
+		}
+		for j := range batch {
+			e := &batch[j]
+			if err := demux.Reprocess(e); err != nil {
+				logrus.Infof("Height %d event %d type %q still fails: %s", e.Height, e.EventIndex, e.Type, err)
+				failed++
+				continue
+			}
+			if processed == 0 {
+				firstHeight = e.Height
+			}
+			processed++
+		}
+		if err := db.Commit(); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	logrus.Infof("Reprocessed %d events, %d failed", processed, failed)
+	if processed != 0 {
+		logrus.Infof("First reprocessed event at height %d", firstHeight)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+// Exports the database up to a height into a snapshot file, or imports one into an empty
+// database. Midgard continues after the height of the snapshot.
+//
+//	snapshot config export file [heightOrTimestamp]
+//	snapshot config import file
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"gitlab.com/thorchain/midgard/config"
+	"gitlab.com/thorchain/midgard/internal/api"
+	"gitlab.com/thorchain/midgard/internal/db"
+)
+
+const usage = `Usage: $ snapshot config export file [heightOrTimestamp]
+       $ snapshot config import file`
+
+func main() {
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: "2006-01-02 15:04:05", FullTimestamp: true})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	args := os.Args[1:]
+	valid := (len(args) == 3 || len(args) == 4) && args[1] == "export" ||
+		len(args) == 3 && args[1] == "import"
+	if !valid {
+		logrus.Fatalf("Wrong arguments %q\n%s", args, usage)
+	}
+
+	var c config.Config = config.ReadConfigFrom(args[0])
+	ctx := context.Background()
+
+	if args[1] == "export" {
+		// Reads only, the schema is left as is.
+		db.SetupReadOnly(&c.TimeScale)
+		var height int64
+		if len(args) == 4 {
+			heightOrTimestamp, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				logrus.Fatal("Couldn't parse height or timestamp: ", args[3])
+			}
+			height, _, err = api.TimestampAndHeight(ctx, heightOrTimestamp)
+			if err != nil {
+				logrus.Fatal("Couldn't find height for ", heightOrTimestamp)
+			}
+		}
+		exportSnapshot(ctx, args[2], height)
+	} else {
+		db.LockWriter(&c.TimeScale)
+		db.Setup(&c.TimeScale)
+		importSnapshot(ctx, args[2])
+	}
+}
+
+func exportSnapshot(ctx context.Context, path string, height int64) {
+	file, err := os.Create(path)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	w := bufio.NewWriter(file)
+	manifest, err := db.ExportSnapshot(ctx, w, height)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		os.Remove(path)
+		logrus.Fatal("Export failed: ", err)
+	}
+	logrus.Infof("Exported chain %s up to height %d into %s",
+		manifest.ChainID, manifest.Height, path)
+}
+
+func importSnapshot(ctx context.Context, path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	defer file.Close()
+	manifest, err := db.ImportSnapshot(ctx, bufio.NewReader(file))
+	if err != nil {
+		logrus.Fatal("Import failed: ", err)
+	}
+	logrus.Infof("Imported chain %s up to height %d, Midgard continues at height %d",
+		manifest.ChainID, manifest.Height, manifest.Height+1)
+}
@@ -64,10 +64,9 @@ func BondDetails(ctx context.Context, thorNodeUrl string) {
 			COALESCE(from_addr, to_addr),
 			bond_type,
 			E8,
-			height
+			block_height
 		FROM bond_events
-		JOIN block_log ON block_timestamp = timestamp
-		ORDER BY block_timestamp ASC
+		ORDER BY block_height, event_index
 	`
 	rows, err := db.Query(ctx, bondEventsQ)
 	if err != nil {
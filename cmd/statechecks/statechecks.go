@@ -382,6 +382,7 @@ type EventTable struct {
 func findEventTables(ctx context.Context) []EventTable {
 	blockTimestampTables := findTablesWithColumns(ctx, "block_timestamp")
 	blockTimestampTables["block_pool_depths"] = false
+	blockTimestampTables["block_pool_units"] = false
 
 	poolTables := findTablesWithColumns(ctx, "pool")
 	assetTables := findTablesWithColumns(ctx, "asset")
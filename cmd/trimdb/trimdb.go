@@ -4,7 +4,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"strconv"
 
@@ -47,58 +46,8 @@ func main() {
 		logrus.Fatal(err)
 	}
 
-	logrus.Infof("Deleting rows including and after height %d , timestamp %d", height, timestamp)
-	tables := GetTableColumns(ctx)
-	for table, columns := range tables {
-		if columns["block_timestamp"] {
-			logrus.Infof("%s  deleting by block_timestamp", table)
-			DeleteAfter(table, "block_timestamp", timestamp.ToI())
-		} else if columns["height"] {
-			logrus.Infof("%s deleting by height", table)
-			DeleteAfter(table, "height", height)
-		} else if table == "constants" {
-			logrus.Infof("Skipping table %s", table)
-		} else {
-			logrus.Warnf("talbe %s has no good column", table)
-		}
-	}
-}
-
-func DeleteAfter(table string, columnName string, value int64) {
-	q := fmt.Sprintf("DELETE FROM %s WHERE $1 <= %s", table, columnName)
-	_, err := db.Exec(q, value)
-	if err != nil {
-		logrus.Fatal("delete failed: ", err)
-	}
-}
-
-type TableMap map[string]map[string]bool
-
-func GetTableColumns(ctx context.Context) TableMap {
-	q := `
-	SELECT
-		table_name,
-		column_name
-	FROM information_schema.columns
-	WHERE table_schema='midgard'
-	`
-	rows, err := db.Query(ctx, q)
+	err = db.DeleteBlocksFrom(ctx, height, timestamp)
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	defer rows.Close()
-
-	ret := TableMap{}
-	for rows.Next() {
-		var table, column string
-		err := rows.Scan(&table, &column)
-		if err != nil {
-			logrus.Fatal(err)
-		}
-		if _, ok := ret[table]; !ok {
-			ret[table] = map[string]bool{}
-		}
-		ret[table][column] = true
-	}
-	return ret
 }
@@ -27,10 +27,24 @@ type Config struct {
 
 	ThorChain struct {
 		TendermintURL               string   `json:"tendermint_url" split_words:"true"`
+		TendermintURLs              []string `json:"tendermint_urls" split_words:"true"`
 		ThorNodeURL                 string   `json:"thornode_url" split_words:"true"`
 		ReadTimeout                 Duration `json:"read_timeout" split_words:"true"`
 		LastChainBackoff            Duration `json:"last_chain_backoff" split_words:"true"`
 		ProxiedWhitelistedEndpoints []string `json:"proxied_whitelisted_endpoints" split_words:"true"`
+
+		// Number of block batches fetched in parallel, and how many batches they may get
+		// ahead of the writer.
+		FetchWorkers       int `json:"fetch_workers" split_words:"true"`
+		FetchPrefetchDepth int `json:"fetch_prefetch_depth" split_words:"true"`
+
+		// When a block doesn't continue the chain in the database, trim the database back to
+		// the last height consistent with the node and exit, instead of just exiting.
+		TrimOnDivergence bool `json:"trim_on_divergence" split_words:"true"`
+
+		// Without NewBlock event from the Tendermint websocket for this long, blocks are polled
+		// for every LastChainBackoff until the subscription is renewed.
+		NewBlockTimeout Duration `json:"new_block_timeout" split_words:"true"`
 	} `json:"thorchain"`
 
 	Websockets struct {
@@ -38,7 +52,18 @@ type Config struct {
 		ConnectionLimit int  `json:"connection_limit" split_words:"true"`
 	} `json:"websockets" split_words:"true"`
 
+	// Local copy of the fetched blocks, for rebuilding the database without Tendermint.
+	Archive struct {
+		// Directory of the archive. Blocks are archived once written when set.
+		Dir string `json:"dir" split_words:"true"`
+		// Read blocks from the archive first, and from Tendermint from where it ends.
+		Replay bool `json:"replay" split_words:"true"`
+	} `json:"archive"`
+
 	UsdPools []string `json:"usdpools" split_words:"true"`
+
+	// Replaces the chain corrections which come with the binary when set.
+	CorrectionsFile string `json:"corrections_file" split_words:"true"`
 }
 
 func (d Duration) WithDefault(def time.Duration) time.Duration {
@@ -100,21 +125,34 @@ func setDefaultUrls(c *Config) {
 		log.Fatal().Err(err).Msg("Exit on malformed THORNode REST URL")
 	}
 
-	if c.ThorChain.TendermintURL == "" {
+	if c.ThorChain.TendermintURL == "" && len(c.ThorChain.TendermintURLs) == 0 {
 		c.ThorChain.TendermintURL = "http://localhost:26657/websocket"
 		log.Info().Msgf("Default Tendermint RPC URL to %q", c.ThorChain.TendermintURL)
-	} else {
-		log.Info().Msgf("Tendermint RPC URL is set to %q", c.ThorChain.TendermintURL)
+	}
+	// TendermintURL is kept for compatibility, it becomes the first of the endpoints.
+	if c.ThorChain.TendermintURL != "" && !contains(c.ThorChain.TendermintURLs, c.ThorChain.TendermintURL) {
+		c.ThorChain.TendermintURLs = append([]string{c.ThorChain.TendermintURL}, c.ThorChain.TendermintURLs...)
+	}
+	for _, tendermintURL := range c.ThorChain.TendermintURLs {
+		log.Info().Msgf("Tendermint RPC URL is set to %q", tendermintURL)
+		if _, err := url.Parse(tendermintURL); err != nil {
+			log.Fatal().Err(err).Msg("Exit on malformed Tendermint RPC URL")
+		}
 	}
 	if c.TimeScale.MaxOpenConns == 0 {
 		c.TimeScale.MaxOpenConns = 80
 		log.Info().Msgf("Default TimeScale.MaxOpenConnections: %d",
 			c.TimeScale.MaxOpenConns)
 	}
-	_, err := url.Parse(c.ThorChain.TendermintURL)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Exit on malformed Tendermint RPC URL")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
 	}
+	return false
 }
 
 func ReadConfigFrom(filename string) Config {
@@ -63,6 +63,7 @@ func InitHandler(nodeURL string, proxiedWhitelistedEndpoints []string) {
 	router.HandlerFunc(http.MethodGet, "/v2/debug/timers", timer.ServeHTTP)
 	router.HandlerFunc(http.MethodGet, "/v2/debug/usd", stat.ServeUSDDebug)
 	router.Handle(http.MethodGet, "/v2/debug/block/:id", debugBlock)
+	router.Handle(http.MethodGet, "/v2/debug/quarantine", debugQuarantine)
 
 	for _, endpoint := range proxiedWhitelistedEndpoints {
 		midgardPath := proxiedPrefix + endpoint
@@ -75,9 +76,13 @@ func InitHandler(nodeURL string, proxiedWhitelistedEndpoints []string) {
 	addMeasured(router, "/v2/health", jsonHealth)
 	addMeasured(router, "/v2/history/swaps", jsonSwapHistory)
 	addMeasured(router, "/v2/history/depths/:pool", jsonDepths)
+	addMeasured(router, "/v2/history/candles/:pool", jsonCandles)
 	addMeasured(router, "/v2/history/earnings", jsonEarningsHistory)
 	addMeasured(router, "/v2/history/liquidity_changes", jsonLiquidityHistory)
 	addMeasured(router, "/v2/history/tvl", jsonTVLHistory)
+	addMeasured(router, "/v2/history/affiliates", jsonAffiliateHistory)
+	addMeasured(router, "/v2/history/member/:addr", jsonMemberHistory)
+	addMeasured(router, "/v2/affiliates", jsonAffiliates)
 	addMeasured(router, "/v2/network", jsonNetwork)
 	router.Handle(http.MethodGet, "/v2/nodes", cachedJsonNodes())
 	addMeasured(router, "/v2/pools", jsonPools)
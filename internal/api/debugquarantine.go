@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
+)
+
+const maxQuarantineLimit = 1000
+
+// Lists the events in the quarantine, optionally filtered by type.
+func debugQuarantine(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	limit := 100
+	if s := query.Get("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 1 || maxQuarantineLimit < limit {
+			miderr.BadRequestF("limit must be an integer in [1, %d]: %q", maxQuarantineLimit, s).ReportHTTP(w)
+			return
+		}
+	}
+
+	events, err := record.QuarantinedEvents(r.Context(), query.Get("type"), limit)
+	if err != nil {
+		respError(w, err)
+		return
+	}
+	if events == nil {
+		events = []record.QuarantinedEvent{}
+	}
+	respJSON(w, events)
+}
@@ -35,10 +35,22 @@ type Health struct {
 func jsonHealth(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	height, _, _ := timeseries.LastBlock()
 	synced := InSync()
+	var replicas *[]oapigen.ReplicaHealth
+	if health := db.ReplicasHealth(); len(health) != 0 {
+		list := make([]oapigen.ReplicaHealth, len(health))
+		for i, replica := range health {
+			list[i] = oapigen.ReplicaHealth{
+				Healthy:   replica.Healthy,
+				HeightLag: util.IntStr(replica.HeightLag),
+			}
+		}
+		replicas = &list
+	}
 	respJSON(w, oapigen.HealthResponse{
 		InSync:        synced,
 		Database:      true,
 		ScannerHeight: util.IntStr(height + 1),
+		ReadReplicas:  replicas,
 	})
 }
 
@@ -141,6 +153,66 @@ func toOapiDepthResponse(
 	return
 }
 
+func jsonCandles(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pool := ps[0].Value
+
+	if !timeseries.PoolExists(pool) {
+		miderr.BadRequestF("Unknown pool: %s", pool).ReportHTTP(w)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var usd bool
+	switch currency := query.Get("currency"); currency {
+	case "", "rune":
+	case "usd":
+		usd = true
+	default:
+		miderr.BadRequestF("Invalid currency: %s, should be rune or usd", currency).ReportHTTP(w)
+		return
+	}
+
+	buckets, merr := db.BucketsFromQuery(r.Context(), query)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	candles, err := stat.PoolPriceCandles(r.Context(), buckets, pool, usd)
+	if err != nil {
+		miderr.InternalErrE(err).ReportHTTP(w)
+		return
+	}
+	var result oapigen.CandleHistoryResponse = toOapiCandleResponse(buckets, candles, usd)
+	respJSON(w, result)
+}
+
+func toOapiCandleResponse(buckets db.Buckets, candles []stat.PriceCandle, usd bool) (
+	result oapigen.CandleHistoryResponse) {
+	result.Intervals = make(oapigen.CandleHistoryIntervals, 0, len(candles))
+	for _, candle := range candles {
+		item := oapigen.CandleHistoryItem{
+			StartTime: util.IntStr(candle.Window.From.ToI()),
+			EndTime:   util.IntStr(candle.Window.Until.ToI()),
+			Open:      floatStr(candle.Open),
+			High:      floatStr(candle.High),
+			Low:       floatStr(candle.Low),
+			Close:     floatStr(candle.Close),
+			Count:     util.IntStr(candle.Count),
+			Volume:    util.IntStr(candle.Volume),
+		}
+		if usd {
+			volumeUSD := floatStr(float64(candle.Volume) * candle.RunePriceUSD)
+			item.VolumeUSD = &volumeUSD
+		}
+		result.Intervals = append(result.Intervals, item)
+	}
+	result.Meta.StartTime = util.IntStr(buckets.Start().ToI())
+	result.Meta.EndTime = util.IntStr(buckets.End().ToI())
+	return
+}
+
 func jsonSwapHistory(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	query := r.URL.Query()
 
@@ -260,6 +332,98 @@ func toTVLHistoryResponse(depths []stat.TVLDepthBucket, bonds []stat.BondBucket)
 	return
 }
 
+func jsonAffiliateHistory(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+
+	buckets, merr := db.BucketsFromQuery(r.Context(), query)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	affiliates, err := stat.AffiliateHistory(r.Context(), buckets, query.Get("address"))
+	if err != nil {
+		miderr.InternalErrE(err).ReportHTTP(w)
+		return
+	}
+	var result oapigen.AffiliateHistoryResponse = toAffiliateHistoryResponse(affiliates)
+	if buckets.OneInterval() {
+		result.Intervals = oapigen.AffiliateHistoryIntervals{}
+	}
+	respJSON(w, result)
+}
+
+func toAffiliateHistoryResponse(buckets []stat.AffiliateBucket) (result oapigen.AffiliateHistoryResponse) {
+	var count, volume, fees int64
+	result.Intervals = make(oapigen.AffiliateHistoryIntervals, 0, len(buckets))
+	for _, bucket := range buckets {
+		count += bucket.Count
+		volume += bucket.VolumeE8
+		fees += bucket.FeesE8
+		result.Intervals = append(result.Intervals, oapigen.AffiliateHistoryItem{
+			StartTime: util.IntStr(bucket.Window.From.ToI()),
+			EndTime:   util.IntStr(bucket.Window.Until.ToI()),
+			Count:     util.IntStr(bucket.Count),
+			Volume:    util.IntStr(bucket.VolumeE8),
+			Fees:      util.IntStr(bucket.FeesE8),
+		})
+	}
+	result.Meta = oapigen.AffiliateHistoryItem{
+		StartTime: result.Intervals[0].StartTime,
+		EndTime:   result.Intervals[len(buckets)-1].EndTime,
+		Count:     util.IntStr(count),
+		Volume:    util.IntStr(volume),
+		Fees:      util.IntStr(fees),
+	}
+	return
+}
+
+func jsonAffiliates(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+
+	buckets, merr := db.BucketsFromQuery(r.Context(), query)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	orderBy := query.Get("sort")
+	switch orderBy {
+	case "":
+		orderBy = stat.AffiliatesByVolume
+	case stat.AffiliatesByVolume, stat.AffiliatesByFees:
+	default:
+		miderr.BadRequestF("Invalid sort: %s, should be volume or fees", orderBy).ReportHTTP(w)
+		return
+	}
+
+	limit := 10
+	if s := query.Get("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 1 || 100 < limit {
+			miderr.BadRequestF("Invalid limit: %s, should be between [1..100]", s).ReportHTTP(w)
+			return
+		}
+	}
+
+	affiliates, err := stat.AffiliateLeaderboard(r.Context(), buckets.Window(), orderBy, limit)
+	if err != nil {
+		miderr.InternalErrE(err).ReportHTTP(w)
+		return
+	}
+	result := make(oapigen.AffiliatesResponse, len(affiliates))
+	for i, a := range affiliates {
+		result[i] = oapigen.AffiliateDetails{
+			Address: a.Address,
+			Count:   util.IntStr(a.Count),
+			Volume:  util.IntStr(a.VolumeE8),
+			Fees:    util.IntStr(a.FeesE8),
+		}
+	}
+	respJSON(w, result)
+}
+
 type Network struct {
 	ActiveBonds     []string `json:"activeBonds,string"`
 	ActiveNodeCount int      `json:"activeNodeCount,string"`
@@ -292,7 +456,30 @@ type Network struct {
 }
 
 func jsonNetwork(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	network, err := timeseries.GetNetworkData(r.Context())
+	asOf, merr := asOfParam(r)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	var network model.Network
+	var err error
+	if asOf == nil {
+		network, err = timeseries.GetNetworkData(r.Context())
+	} else {
+		var depths timeseries.DepthMap
+		depths, err = stat.PoolDepthsAt(r.Context(), nil, asOf.timestamp)
+		if err != nil {
+			respError(w, err)
+			return
+		}
+		runeE8DepthPerPool := map[string]int64{}
+		for pool, depth := range depths {
+			runeE8DepthPerPool[pool] = depth.RuneDepth
+		}
+		network, err = timeseries.GetNetworkDataAt(
+			r.Context(), asOf.height, asOf.timestamp, runeE8DepthPerPool)
+	}
 	if err != nil {
 		respError(w, err)
 		return
@@ -378,10 +565,78 @@ func cachedJsonNodes() httprouter.Handle {
 	return cachedHandler.ServeHTTP
 }
 
+// The block of the height or timestamp parameter, the pools and network endpoints return the
+// state after it instead of the latest.
+type asOfBlock struct {
+	height    int64
+	timestamp db.Nano
+}
+
+// Returns nil without height and timestamp parameters.
+func asOfParam(r *http.Request) (*asOfBlock, miderr.Err) {
+	query := r.URL.Query()
+	heightStr, timestampStr := query.Get("height"), query.Get("timestamp")
+	if heightStr == "" && timestampStr == "" {
+		return nil, nil
+	}
+	if heightStr != "" && timestampStr != "" {
+		return nil, miderr.BadRequest("Specify max one of height and timestamp")
+	}
+
+	var ret asOfBlock
+	var err error
+	if heightStr != "" {
+		ret.height, err = strconv.ParseInt(heightStr, 10, 64)
+		if err != nil || ret.height < 1 {
+			return nil, miderr.BadRequestF("Parameter 'height' is not a positive integer: %s", heightStr)
+		}
+		ret.timestamp, err = timeseries.BlockAtHeight(r.Context(), ret.height)
+	} else {
+		var second int64
+		second, err = strconv.ParseInt(timestampStr, 10, 64)
+		if err != nil {
+			return nil, miderr.BadRequestF("Parameter 'timestamp' is not integer: %s", timestampStr)
+		}
+		// The last block within the second.
+		ret.height, ret.timestamp, err = timeseries.BlockAtTime(
+			r.Context(), db.Second(second+1).ToNano()-1)
+	}
+	if merr, ok := err.(miderr.Err); ok {
+		return nil, merr
+	}
+	if err != nil {
+		return nil, miderr.InternalErrE(err)
+	}
+	return &ret, nil
+}
+
+// The timestamp of the block, or of the last block.
+func (asOf *asOfBlock) moment() db.Nano {
+	if asOf == nil {
+		_, lastTime, _ := timeseries.LastBlock()
+		return db.TimeToNano(lastTime)
+	}
+	return asOf.timestamp
+}
+
+func runePriceUSDAt(ctx context.Context, asOf *asOfBlock) (float64, error) {
+	if asOf == nil {
+		return stat.RunePriceUSD(), nil
+	}
+	return stat.RunePriceUSDAt(ctx, asOf.timestamp)
+}
+
 // Filters out Suspended pools.
 // If there is a status url parameter then returns pools with that status only.
-func poolsWithRequestedStatus(r *http.Request, statusMap map[string]string) ([]string, error) {
-	pools, err := timeseries.PoolsWithDeposit(r.Context())
+func poolsWithRequestedStatus(r *http.Request, statusMap map[string]string, asOf *asOfBlock) (
+	[]string, error) {
+	var pools []string
+	var err error
+	if asOf == nil {
+		pools, err = timeseries.PoolsWithDeposit(r.Context())
+	} else {
+		pools, err = timeseries.PoolsWithDepositAt(r.Context(), asOf.timestamp)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -418,9 +673,31 @@ type poolAggregates struct {
 	runeE8DepthPerPool  map[string]int64
 }
 
-func getPoolAggregates(ctx context.Context, pools []string) (*poolAggregates, error) {
-	assetE8DepthPerPool, runeE8DepthPerPool, timestamp := timeseries.AssetAndRuneDepths()
-	now := db.TimeToSecond(timestamp)
+func getPoolAggregates(ctx context.Context, pools []string, asOf *asOfBlock) (*poolAggregates, error) {
+	var assetE8DepthPerPool, runeE8DepthPerPool, poolUnits map[string]int64
+	var now db.Second
+	if asOf == nil {
+		var timestamp time.Time
+		assetE8DepthPerPool, runeE8DepthPerPool, timestamp = timeseries.AssetAndRuneDepths()
+		now = db.TimeToSecond(timestamp)
+		poolUnits = timeseries.Latest.GetState().PoolUnits
+	} else {
+		depths, err := stat.PoolDepthsAt(ctx, pools, asOf.timestamp)
+		if err != nil {
+			return nil, err
+		}
+		assetE8DepthPerPool = make(map[string]int64, len(depths))
+		runeE8DepthPerPool = make(map[string]int64, len(depths))
+		for pool, depth := range depths {
+			assetE8DepthPerPool[pool] = depth.AssetDepth
+			runeE8DepthPerPool[pool] = depth.RuneDepth
+		}
+		now = asOf.timestamp.ToSecond()
+		poolUnits, err = stat.PoolsUnitsAt(ctx, pools, asOf.timestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
 	dayAgo := now - 24*60*60
 
 	dailyVolumes, err := stat.PoolsTotalVolume(ctx, pools, dayAgo.ToNano(), now.ToNano())
@@ -428,11 +705,6 @@ func getPoolAggregates(ctx context.Context, pools []string) (*poolAggregates, er
 		return nil, err
 	}
 
-	poolUnits, err := stat.CurrentPoolsLiquidityUnits(ctx, pools)
-	if err != nil {
-		return nil, err
-	}
-
 	week := db.Window{From: now - 7*24*60*60, Until: now}
 	poolAPYs, err := timeseries.GetPoolAPY(ctx, runeE8DepthPerPool, pools, week)
 
@@ -479,25 +751,34 @@ func buildPoolDetail(
 }
 
 func jsonPools(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	_, lastTime, _ := timeseries.LastBlock()
-	statusMap, err := timeseries.GetPoolsStatuses(r.Context(), db.Nano(lastTime.UnixNano()))
+	asOf, merr := asOfParam(r)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	statusMap, err := timeseries.GetPoolsStatuses(r.Context(), asOf.moment())
 	if err != nil {
 		respError(w, err)
 		return
 	}
-	pools, err := poolsWithRequestedStatus(r, statusMap)
+	pools, err := poolsWithRequestedStatus(r, statusMap, asOf)
 	if err != nil {
 		respError(w, err)
 		return
 	}
 
-	aggregates, err := getPoolAggregates(r.Context(), pools)
+	aggregates, err := getPoolAggregates(r.Context(), pools, asOf)
 	if err != nil {
 		respError(w, err)
 		return
 	}
 
-	runePriceUsd := stat.RunePriceUSD()
+	runePriceUsd, err := runePriceUSDAt(r.Context(), asOf)
+	if err != nil {
+		respError(w, err)
+		return
+	}
 
 	poolsResponse := make(oapigen.PoolsResponse, len(pools))
 	for i, pool := range pools {
@@ -511,24 +792,45 @@ func jsonPools(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 func jsonPool(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	pool := ps[0].Value
 
-	if !timeseries.PoolExistsNow(pool) {
-		miderr.BadRequestF("Unknown pool: %s", pool).ReportHTTP(w)
+	asOf, merr := asOfParam(r)
+	if merr != nil {
+		merr.ReportHTTP(w)
 		return
 	}
 
-	status, err := timeseries.PoolStatus(r.Context(), pool)
+	var status string
+	var err error
+	if asOf == nil {
+		if !timeseries.PoolExistsNow(pool) {
+			miderr.BadRequestF("Unknown pool: %s", pool).ReportHTTP(w)
+			return
+		}
+		status, err = timeseries.PoolStatus(r.Context(), pool)
+	} else {
+		var statusMap map[string]string
+		statusMap, err = timeseries.GetPoolsStatuses(r.Context(), asOf.timestamp)
+		status = poolStatusFromMap(pool, statusMap)
+	}
 	if err != nil {
 		miderr.InternalErrE(err).ReportHTTP(w)
 		return
 	}
 
-	aggregates, err := getPoolAggregates(r.Context(), []string{pool})
+	aggregates, err := getPoolAggregates(r.Context(), []string{pool}, asOf)
 	if err != nil {
 		miderr.InternalErrE(err).ReportHTTP(w)
 		return
 	}
+	if asOf != nil && (aggregates.assetE8DepthPerPool[pool] == 0 || aggregates.runeE8DepthPerPool[pool] == 0) {
+		miderr.BadRequestF("Unknown pool at height %d: %s", asOf.height, pool).ReportHTTP(w)
+		return
+	}
 
-	runePriceUsd := stat.RunePriceUSD()
+	runePriceUsd, err := runePriceUSDAt(r.Context(), asOf)
+	if err != nil {
+		miderr.InternalErrE(err).ReportHTTP(w)
+		return
+	}
 
 	var poolResponse oapigen.PoolResponse
 	poolResponse = oapigen.PoolResponse(
@@ -578,6 +880,74 @@ func jsonMemberDetails(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	})
 }
 
+func jsonMemberHistory(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	addr := ps[0].Value
+	query := r.URL.Query()
+
+	var pool *string
+	poolParam := query.Get("pool")
+	if poolParam != "" {
+		pool = &poolParam
+		if !timeseries.PoolExists(*pool) {
+			miderr.BadRequestF("Unknown pool: %s", *pool).ReportHTTP(w)
+			return
+		}
+	}
+
+	buckets, merr := db.BucketsFromQuery(r.Context(), query)
+	if merr != nil {
+		merr.ReportHTTP(w)
+		return
+	}
+
+	history, err := stat.MemberHistory(r.Context(), buckets, addr, pool)
+	if err != nil {
+		miderr.InternalErrE(err).ReportHTTP(w)
+		return
+	}
+	if len(history) == 0 {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	var result oapigen.MemberHistoryResponse = toOapiMemberHistoryResponse(buckets, history)
+	respJSON(w, result)
+}
+
+func toOapiMemberHistoryResponse(buckets db.Buckets, history []stat.MemberPoolHistory) (
+	result oapigen.MemberHistoryResponse) {
+	result.Meta.StartTime = util.IntStr(buckets.Start().ToI())
+	result.Meta.EndTime = util.IntStr(buckets.End().ToI())
+	result.Pools = make([]oapigen.MemberPoolHistory, len(history))
+	for i, poolHistory := range history {
+		intervals := make([]oapigen.MemberHistoryItem, len(poolHistory.Buckets))
+		for j, bucket := range poolHistory.Buckets {
+			intervals[j] = oapigen.MemberHistoryItem{
+				StartTime:       util.IntStr(bucket.Window.From.ToI()),
+				EndTime:         util.IntStr(bucket.Window.Until.ToI()),
+				LiquidityUnits:  util.IntStr(bucket.LiquidityUnits),
+				PoolUnits:       util.IntStr(bucket.PoolUnits),
+				PoolShare:       floatStr(bucket.PoolShare()),
+				AssetRedeemable: util.IntStr(bucket.AssetRedeemable),
+				RuneRedeemable:  util.IntStr(bucket.RuneRedeemable),
+				AssetPrice:      floatStr(bucket.AssetPrice),
+				Value:           util.IntStr(bucket.Value),
+				HodlValue:       util.IntStr(bucket.HodlValue),
+				ValueVsHodl:     util.IntStr(bucket.Value - bucket.HodlValue),
+				AddedValue:      util.IntStr(bucket.AddedValue),
+				WithdrawnValue:  util.IntStr(bucket.WithdrawnValue),
+				RealizedGain:    util.IntStr(bucket.RealizedGain),
+				UnrealizedGain:  util.IntStr(bucket.UnrealizedGain),
+			}
+		}
+		result.Pools[i] = oapigen.MemberPoolHistory{
+			Pool:      poolHistory.Pool,
+			Address:   poolHistory.Address,
+			Intervals: intervals,
+		}
+	}
+	return
+}
+
 func calculateJsonStats(ctx context.Context, w io.Writer) error {
 	state := timeseries.Latest.GetState()
 	now := db.NowSecond()
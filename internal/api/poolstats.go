@@ -43,11 +43,6 @@ func setAggregatesStats(
 		return
 	}
 
-	poolUnitsMap, err := stat.CurrentPoolsLiquidityUnits(ctx, []string{pool})
-	if err != nil {
-		return miderr.InternalErrE(err)
-	}
-
 	poolAPY, err := timeseries.GetSinglePoolAPY(
 		ctx, poolInfo.RuneDepth, pool, buckets.Window())
 	if err != nil {
@@ -60,7 +55,7 @@ func setAggregatesStats(
 		return
 	}
 
-	poolUnits := poolUnitsMap[pool]
+	poolUnits := state.PoolUnits[pool]
 	price := poolInfo.AssetPrice()
 	priceUSD := price * stat.RunePriceUSD()
 
@@ -23,6 +23,13 @@ const (
 type aggregateParams struct {
 	lowerQuery  string
 	higherQuery string
+	// Kept as a rollup table instead of a continuous aggregate of TimescaleDB.
+	materialized bool
+}
+
+// Continuous aggregates are maintained by TimescaleDB, the others by refreshAggregates.
+func (a aggregateParams) continuous() bool {
+	return storage == StorageTimescale && !a.materialized
 }
 
 var aggregates = map[string]aggregateParams{}
@@ -32,15 +39,27 @@ var aggregates = map[string]aggregateParams{}
 // the daily aggregate into higher aggregates.
 //
 // The `lowerQuery` is the query template for creating the materialized view
-// from a hypertable. Should contain a single %s hole which will be filled by
+// from a hypertable. Should contain a %[1]s hole which will be filled by
 // a `time_bucket(..., block_timestamp)`, and this column should be named
-// `bucket_start`.
+// `bucket_start`. A %[2]s hole in its WHERE clause is filled with a condition on
+// block_timestamp (see storage.go).
 //
 // The `higherQuery` is the query template for creating views from the daily aggregate.
 // Should contain a single %s hole which will be filled by a `nano_trunc(..., d.bucket_start)`,
 // so the daily aggregate should be aliased as `d`.
 func RegisterAggregate(name string, lowerQuery string, upperQuery string) {
-	aggregates[name] = aggregateParams{lowerQuery, upperQuery}
+	aggregates[name] = aggregateParams{lowerQuery: lowerQuery, higherQuery: upperQuery}
+}
+
+// RegisterMaterializedAggregate is RegisterAggregate for lower queries which TimescaleDB can't
+// aggregate continuously, like joins. Their exact buckets are rollup tables up to a watermark
+// (see storage.go), and every refresh adds the buckets since the previous one.
+func RegisterMaterializedAggregate(name string, lowerQuery string, upperQuery string) {
+	aggregates[name] = aggregateParams{
+		lowerQuery:   lowerQuery,
+		higherQuery:  upperQuery,
+		materialized: true,
+	}
 }
 
 func AggregatesDdl() string {
@@ -52,6 +71,7 @@ func AggregatesDdl() string {
 		CREATE SCHEMA midgard_agg;
 
 	`)
+	fmt.Fprint(&b, watermarksDdl)
 
 	// Sort to iterate in deterministic order.
 	// We need this to avoid unnecessarily recreating the 'aggregate' schema.
@@ -65,15 +85,8 @@ func AggregatesDdl() string {
 		aggregate := aggregates[name]
 		for _, bucket := range intervals {
 			if bucket.exact {
-				bucketField := fmt.Sprintf("time_bucket('%d', block_timestamp)",
-					bucket.minDuration*1e9)
-				q := strings.TrimSpace(fmt.Sprintf(aggregate.lowerQuery, bucketField))
-				fmt.Fprint(&b, `
-					CREATE MATERIALIZED VIEW midgard_agg.`+name+`_`+bucket.name+`
-					WITH (timescaledb.continuous) AS
-					`+q+`
-					WITH NO DATA;
-				`)
+				fmt.Fprint(&b, exactAggregateDdl(name+"_"+bucket.name, aggregate.lowerQuery,
+					bucket.minDuration, aggregate.continuous()))
 			} else {
 				bucketField := fmt.Sprintf("nano_trunc('%s', d.bucket_start)",
 					bucket.name)
@@ -103,7 +116,7 @@ func refreshAggregates(ctx context.Context) {
 	log.Debug().Msg("Refreshing aggregates")
 
 	refreshEnd := LastBlockTimestamp() - 5*60*1e9
-	for name := range aggregates {
+	for name, aggregate := range aggregates {
 		for _, bucket := range intervals {
 			if !bucket.exact {
 				continue
@@ -111,9 +124,8 @@ func refreshAggregates(ctx context.Context) {
 			if ctx.Err() != nil {
 				return
 			}
-			q := fmt.Sprintf("CALL refresh_continuous_aggregate('midgard_agg.%s_%s', NULL, '%d')",
-				name, bucket.name, refreshEnd)
-			_, err := theDB.Exec(q)
+			err := refreshExactAggregate(ctx, name+"_"+bucket.name, aggregate.lowerQuery,
+				bucket.minDuration, refreshEnd, aggregate.continuous())
 			if err != nil {
 				log.Error().Err(err).Msgf("Refreshing %s_%s", name, bucket.name)
 			}
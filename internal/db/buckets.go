@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
+	"time"
 
 	"gitlab.com/thorchain/midgard/internal/util/miderr"
 )
@@ -33,11 +33,6 @@ type IntervalDescription struct {
 	interval Interval
 	// Name used in JSON API and in aggregate views
 	name string
-	// This is used for the sql `date_trunc` function.	`date_trunc` does not accept '5 minute'
-	// as a parameter, instead we round every timestamp to the nearest 5min
-	// with (timestamp / 300) * 300.
-	// TODO(huginn): remove when all aggregates are updated
-	dateTruncName string
 	// Can this interval be used for TimescaleDB continuous aggregating?
 	// The interval have to be exact number of seconds (and also start exactly at epoch, which
 	// makes 'week' inexact)
@@ -52,40 +47,37 @@ type IntervalDescription struct {
 }
 
 var intervals = [...]IntervalDescription{
-	{Min5, "5min", "minute", true, 60 * 5, 60 * 5},
-	{Hour, "hour", "hour", true, 60 * 60, 60 * 60},
-	{Day, "day", "day", true, 60 * 60 * 24, 60 * 60 * 24},
-	{Week, "week", "week", false, 60 * 60 * 24 * 7, 60 * 60 * 24 * 7},
-	{Month, "month", "month", false, 60 * 60 * 24 * 28, 60 * 60 * 24 * 31},
-	{Quarter, "quarter", "quarter", false, 60 * 60 * 24 * 28 * 3, 60 * 60 * 24 * 31 * 3},
-	{Year, "year", "year", false, 60 * 60 * 24 * 28 * 12, 60 * 60 * 24 * 31 * 12},
+	{Min5, "5min", true, 60 * 5, 60 * 5},
+	{Hour, "hour", true, 60 * 60, 60 * 60},
+	{Day, "day", true, 60 * 60 * 24, 60 * 60 * 24},
+	{Week, "week", false, 60 * 60 * 24 * 7, 60 * 60 * 24 * 7},
+	{Month, "month", false, 60 * 60 * 24 * 28, 60 * 60 * 24 * 31},
+	{Quarter, "quarter", false, 60 * 60 * 24 * 28 * 3, 60 * 60 * 24 * 31 * 3},
+	{Year, "year", false, 60 * 60 * 24 * 28 * 12, 60 * 60 * 24 * 31 * 12},
 }
 
 // Convenience maps for the `intervals`
 var intervalMap map[Interval]IntervalDescription
-var intervalFromJSONParamMap map[string]Interval
 
-// Initialize the above convenience maps
+// Initialize the above convenience map
 func init() {
 	intervalMap = make(map[Interval]IntervalDescription)
-	intervalFromJSONParamMap = make(map[string]Interval)
 	for _, i := range intervals {
 		intervalMap[i.interval] = i
-		intervalFromJSONParamMap[i.name] = i.interval
 	}
 }
 
 type Seconds []Second
 
 // Bucketing has two modes:
-// a) If interval is given then all timestamps are rounded to the interval boundaries.
+// a) If interval is given then all timestamps are rounded to the boundaries of its width.
 //    Timestamps contains count+1 timestamps, so the last timestamp should be the endTime
 //    of the last bucket.
 // b) If interval is nill, then it's an exact search with from..to parameters.
 //    In this case there are exactly two Timestamps.
 type Buckets struct {
 	Timestamps Seconds
-	interval   *Interval
+	width      *bucketWidth
 }
 
 func OneIntervalBuckets(from, to Second) Buckets {
@@ -121,7 +113,7 @@ func (b Buckets) Window() Window {
 }
 
 func (b Buckets) OneInterval() bool {
-	return b.interval == nil
+	return b.width == nil
 }
 
 const (
@@ -130,53 +122,19 @@ const (
 )
 
 // Returns all the buckets for the window, so other queries don't have to care about gapfill functionality.
-func generateTimestamps(ctx context.Context, interval Interval, w Window) (Seconds, miderr.Err) {
-	// We use an SQL query to use the date_trunc of sql.
-	// It's not important which table we select we just need a timestamp type and we use WHERE 1=0
-	// in order not to actually select any data.
-	// We could consider writing an sql function instead or programming dategeneration in go.
-
-	intervalParams := intervalMap[interval]
-	if intervalParams.maxDuration*cutoffWindowLength < (w.Until - w.From) {
+func generateTimestamps(width bucketWidth, w Window) (Seconds, miderr.Err) {
+	if width.maxDuration()*cutoffWindowLength < (w.Until - w.From) {
 		return nil, miderr.BadRequestF(
 			"Too wide range requested, max allowed intervals (%d).\n%s",
 			maxIntervalCount, usage)
 	}
 
-	q := `
-		WITH gapfill AS (
-			SELECT
-				time_bucket_gapfill($1::BIGINT, block_timestamp, $2::BIGINT, $3::BIGINT) as bucket
-			FROM block_pool_depths
-			WHERE 1=0
-			GROUP BY bucket)
-		SELECT
-			EXTRACT(EPOCH FROM
-				date_trunc($4, to_timestamp(bucket/300*300)))::BIGINT as truncated
-		FROM gapfill
-		GROUP BY truncated
-		ORDER BY truncated ASC
-	`
-
 	// Widen from and until a bit, to make sure we don't loose any at edges.
-	untilParam := w.Until + intervalParams.maxDuration + intervalParams.minDuration
-	fromParam := w.From - intervalParams.maxDuration - intervalParams.minDuration
-	rows, err := Query(ctx, q, intervalParams.minDuration,
-		fromParam, untilParam,
-		intervalParams.dateTruncName)
-	if err != nil {
-		return nil, miderr.InternalErrE(err)
-	}
-	defer rows.Close()
-
+	until := w.Until + width.maxDuration() + width.minDuration()
+	from := w.From - width.maxDuration() - width.minDuration()
 	timestamps := []Second{}
-	for rows.Next() {
-		var timestamp Second
-		err := rows.Scan(&timestamp)
-		if err != nil {
-			return nil, miderr.InternalErrE(err)
-		}
-		timestamps = append(timestamps, timestamp)
+	for t := width.truncate(from); t < until; t = width.next(t) {
+		timestamps = append(timestamps, t)
 	}
 
 	// Leave exactly one timestamp bigger than Until
@@ -184,7 +142,7 @@ func generateTimestamps(ctx context.Context, interval Interval, w Window) (Secon
 	for ; 0 < lastIdx && w.Until <= timestamps[lastIdx-1]; lastIdx-- {
 	}
 	firstIdx := 0
-	for ; firstIdx < len(timestamps) && timestamps[firstIdx+1] <= w.From; firstIdx++ {
+	for ; firstIdx+1 < len(timestamps) && timestamps[firstIdx+1] <= w.From; firstIdx++ {
 	}
 	ret := timestamps[firstIdx : lastIdx+1]
 
@@ -198,8 +156,9 @@ func generateTimestamps(ctx context.Context, interval Interval, w Window) (Secon
 
 // TODO(acsaba): Migrate graphql to use GenerateBuckets.
 func BucketsFromWindow(ctx context.Context, window Window, interval Interval) (ret Buckets, merr miderr.Err) {
-	ret.interval = &interval
-	ret.Timestamps, merr = generateTimestamps(ctx, *ret.interval, window)
+	width := intervalWidth(interval)
+	ret.width = &width
+	ret.Timestamps, merr = generateTimestamps(width, window)
 	if merr != nil {
 		return
 	}
@@ -214,6 +173,10 @@ const usage = `Usage:
 
 With interval parameter you get a series of buckets:
 - Interval possible values: 5min, hour, day, week, month, quarter, year.
+  Also multiples of them and other fixed widths: 15min, 4h, 12hour, 2d, 2week, 6month.
+  Fixed widths start at multiples of the width since 1970-01-01, weeks on Mondays, all in UTC.
+- tz: optional IANA time zone, e.g. Europe/Berlin. Days, weeks, months, quarters and years
+  start at local midnights, also around DST changes.
 - count: optional int, (1..100)
 - from/to: optional int, unix second.
 
@@ -223,6 +186,7 @@ Possible configurations with interval:
 - ?interval=day&count=10&from=1606780800       - next 10 days after from.
 - ?interval=day&from=1606780800&to=1608825600  - days between from and to, returns only the first 100.
 - ?interval=year                               - same as interval=year&from=start_of_chain&to=now
+- ?interval=day&count=10&tz=America/New_York   - last 10 days in New York.
 
 Without interval you get only one interval:
 - ?from=1606780842&to=1608825642               - only meta for this interval
@@ -247,7 +211,7 @@ func restrictBuckets(firstBlock, lastBlock Second, buckets *Buckets) {
 	buckets.Timestamps = buckets.Timestamps[firstok : lastok+1]
 }
 
-func generateBucketsWithInterval(ctx context.Context, from, to *Second, count *int64, interval Interval) (ret Buckets, merr miderr.Err) {
+func generateBucketsWithInterval(ctx context.Context, from, to *Second, count *int64, width bucketWidth) (ret Buckets, merr miderr.Err) {
 	firstSecond := FirstBlockSecond()
 	nowSecond := NowSecond()
 
@@ -258,8 +222,8 @@ func generateBucketsWithInterval(ctx context.Context, from, to *Second, count *i
 		if to == nil {
 			to = &nowSecond
 		}
-		ret.interval = &interval
-		ret.Timestamps, merr = generateTimestamps(ctx, *ret.interval, Window{From: *from, Until: *to})
+		ret.width = &width
+		ret.Timestamps, merr = generateTimestamps(width, Window{From: *from, Until: *to})
 		if merr != nil {
 			return
 		}
@@ -283,11 +247,11 @@ func generateBucketsWithInterval(ctx context.Context, from, to *Second, count *i
 	if from == nil && to == nil {
 		to = &nowSecond
 	}
-	ret.interval = &interval
+	ret.width = &width
 	if to != nil {
 		// to & count was given
-		window := Window{From: *to - Second(*count)*intervalMap[interval].maxDuration, Until: *to}
-		ret.Timestamps, merr = generateTimestamps(ctx, *ret.interval, window)
+		window := Window{From: *to - Second(*count)*width.maxDuration(), Until: *to}
+		ret.Timestamps, merr = generateTimestamps(width, window)
 		if merr != nil {
 			return
 		}
@@ -299,8 +263,8 @@ func generateBucketsWithInterval(ctx context.Context, from, to *Second, count *i
 		return
 	} else {
 		// from & count was given
-		window := Window{From: *from, Until: *from + Second(*count)*intervalMap[interval].maxDuration}
-		ret.Timestamps, merr = generateTimestamps(ctx, *ret.interval, window)
+		window := Window{From: *from, Until: *from + Second(*count)*width.maxDuration()}
+		ret.Timestamps, merr = generateTimestamps(width, window)
 		if merr != nil {
 			return
 		}
@@ -362,32 +326,95 @@ func BucketsFromQuery(ctx context.Context, query url.Values) (Buckets, miderr.Er
 		return Buckets{}, merr
 	}
 
+	tz := query.Get("tz")
+	var location *time.Location
+	if tz != "" {
+		var ok bool
+		location, ok = parseLocation(tz)
+		if !ok {
+			return Buckets{}, miderr.BadRequestF(
+				"Invalid tz '(%s)', it should be an IANA time zone like Europe/Berlin.\n%s",
+				tz, usage)
+		}
+	}
+
 	intervalStr := query.Get("interval")
 	if intervalStr == "" {
+		if tz != "" {
+			return Buckets{}, miderr.BadRequestF(
+				"tz was provided but no interval parameter.\n%s", usage)
+		}
 		return generateBucketsOnlyMeta(ctx, from, to, count)
 	}
-	interval, ok := intervalFromJSONParamMap[strings.ToLower(intervalStr)]
+	width, ok := parseBucketWidth(intervalStr, location)
 	if !ok {
 		return Buckets{}, miderr.BadRequestF(
-			"Invalid interval '(%s)', accepted values: 5min, hour, day, week, month, quarter, year.\n%s",
+			"Invalid interval '(%s)', accepted values: 5min, hour, day, week, month, quarter, year"+
+				" or multiples of them like 15min, 4h, 2week.\n%s",
 			intervalStr, usage)
 	}
 
-	return generateBucketsWithInterval(ctx, from, to, count, interval)
+	return generateBucketsWithInterval(ctx, from, to, count, width)
 }
 
-// Select field that truncates the value considering the buckets.Interval
+// Select field that truncates the value considering the buckets width.
 // Result is date in seconds.
 func SelectTruncatedTimestamp(targetColumn string, buckets Buckets) string {
 	if buckets.OneInterval() {
 		return fmt.Sprintf(`(%d)::BIGINT`, buckets.Start())
 	} else {
-		return fmt.Sprintf(
-			`EXTRACT(EPOCH FROM (date_trunc('%s', to_timestamp(%s/1000000000/300*300))))::BIGINT`,
-			intervalMap[*buckets.interval].dateTruncName, targetColumn)
+		return buckets.width.truncateSQL(targetColumn)
+	}
+}
+
+// The interval of the aggregate the buckets are read from. Local calendar buckets are
+// summed from the widest exact aggregate all their boundaries align with, that's the hour
+// aggregate in zones with whole hour offsets.
+func (b Buckets) aggregate() (Interval, bool) {
+	if b.OneInterval() {
+		return UndefinedInterval, false
+	}
+	interval, ok := b.width.aggregate()
+	if ok || b.width.fixed() {
+		return interval, ok
+	}
+	for i := len(intervals) - 1; 0 <= i; i-- {
+		desc := intervals[i]
+		if !desc.exact {
+			continue
+		}
+		aligned := true
+		for _, t := range b.Timestamps {
+			aligned = aligned && t%desc.minDuration == 0
+		}
+		if aligned {
+			return desc.interval, true
+		}
 	}
+	return UndefinedInterval, false
 }
 
+// Aggregated tells if the buckets are read from an aggregate, whose buckets have to fit in
+// whole into these. Other widths are read from the tables.
+func (b Buckets) Aggregated() bool {
+	_, ok := b.aggregate()
+	return ok
+}
+
+// AggregateName is the interval name of the aggregate the buckets are read from.
 func (b Buckets) AggregateName() string {
-	return intervalMap[*b.interval].name
+	interval, _ := b.aggregate()
+	return intervalMap[interval].name
+}
+
+// HistorySource returns what a history query over the buckets reads, and its timestamp column.
+// Buckets of an interval come from the aggregate of that interval, which TimescaleDB (or
+// refreshAggregates) completes with the rows not materialized yet. Wider buckets are summed from
+// the widest aggregate fitting into them. A single from..to interval, or a width no aggregate
+// fits into, doesn't align with the aggregate buckets, so it's read from the table.
+func (b Buckets) HistorySource(table, aggregate string) (source, timestampColumn string) {
+	if !b.Aggregated() {
+		return table, "block_timestamp"
+	}
+	return "midgard_agg." + aggregate + "_" + b.AggregateName(), "bucket_start"
 }
@@ -212,4 +212,47 @@ func TestBucketFailures(t *testing.T) {
 	bucketFail(t, "interval=year&count=10&from=1&to=100", "specify max 2")
 	bucketFail(t, "interval=year&count=500&to=100", "count out of range")
 	bucketFail(t, "count=123&from=1&to=100", "count", "provided", "no interval")
+	bucketFail(t, "interval=0day", "invalid", "0day")
+	bucketFail(t, "interval=3weeks", "invalid", "3weeks")
+	bucketFail(t, "interval=day&tz=Mars/Olympus", "invalid tz", "Mars/Olympus")
+	bucketFail(t, "tz=Europe/Berlin", "tz", "no interval")
+}
+
+func TestFixedWidth(t *testing.T) {
+	db.SetFirstBlockTimestamp(testdb.StrToNano("2010-01-01 00:00:00"))
+	db.SetLastBlockTimestamp(testdb.StrToNano("2030-01-01 00:00:00"))
+	t0 := testdb.StrToSec("2020-01-01 01:00:00")
+	t1 := testdb.StrToSec("2020-01-01 07:00:00")
+	starts := bucketPass(t, fmt.Sprintf("interval=4h&from=%d&to=%d", t0, t1))
+	require.Equal(t, []string{
+		"2020-01-01 00:00:00",
+		"2020-01-01 04:00:00",
+	}, starts)
+}
+
+func TestCalendarMultiple(t *testing.T) {
+	db.SetFirstBlockTimestamp(testdb.StrToNano("2010-01-01 00:00:00"))
+	db.SetLastBlockTimestamp(testdb.StrToNano("2030-01-01 00:00:00"))
+	t1 := testdb.StrToSec("2021-01-20 00:00:00")
+	starts := bucketPass(t, fmt.Sprintf("interval=2week&to=%d&count=3", t1))
+	// Weeks are aligned to 1970-01-05.
+	require.Equal(t, []string{
+		"2020-12-14 00:00:00",
+		"2020-12-28 00:00:00",
+		"2021-01-11 00:00:00",
+	}, starts)
+}
+
+func TestTimeZone(t *testing.T) {
+	db.SetFirstBlockTimestamp(testdb.StrToNano("2010-01-01 00:00:00"))
+	db.SetLastBlockTimestamp(testdb.StrToNano("2030-01-01 00:00:00"))
+	t0 := testdb.StrToSec("2021-03-13 12:00:00")
+	t1 := testdb.StrToSec("2021-03-15 12:00:00")
+	starts := bucketPass(t, fmt.Sprintf("interval=day&from=%d&to=%d&tz=America/New_York", t0, t1))
+	// Local midnights in UTC, the DST starts on 2021-03-14.
+	require.Equal(t, []string{
+		"2021-03-13 05:00:00",
+		"2021-03-14 05:00:00",
+		"2021-03-15 04:00:00",
+	}, starts)
 }
@@ -0,0 +1,276 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// The zones of the tz parameter, the image has no zoneinfo.
+	_ "time/tzdata"
+)
+
+// BucketWidth is the width of the buckets given by the interval parameter: a fixed duration,
+// aligned to the epoch, or a multiple of a calendar interval.
+//
+//	5min, 15min, 4h, 12hour, 2d        fixed widths
+//	week, 2week, month, quarter, year  calendar widths
+//
+// Multiples of a week are aligned to the Monday 1970-01-05, other calendar multiples to the
+// start of year 0.
+//
+// Calendar widths are in UTC, unless a time zone is given. In a time zone days are calendar days
+// too, so their boundaries are local midnights, also around DST transitions. Minutes and hours
+// stay fixed.
+type bucketWidth struct {
+	// Fixed width, zero for calendar widths.
+	seconds Second
+	// Calendar widths are count times the calendar interval.
+	calendar Interval
+	count    int64
+	// Time zone of the calendar widths, nil for UTC.
+	location *time.Location
+}
+
+var widthUnits = map[string]bucketWidth{
+	"min":     {seconds: 60},
+	"h":       {seconds: 60 * 60},
+	"hour":    {seconds: 60 * 60},
+	"d":       {seconds: 60 * 60 * 24},
+	"day":     {seconds: 60 * 60 * 24},
+	"week":    {calendar: Week, count: 1},
+	"month":   {calendar: Month, count: 1},
+	"quarter": {calendar: Quarter, count: 1},
+	"year":    {calendar: Year, count: 1},
+}
+
+// The multiple is limited only to stay clear of overflows, the interval count is the real limit.
+const maxWidthMultiple = 100000
+
+var widthRegexp = regexp.MustCompile(`^(\d*)([a-z]+)$`)
+
+// ParseBucketWidth parses an interval parameter, e.g. "15min" or "2week", of the time zone
+// location (nil for UTC).
+func parseBucketWidth(s string, location *time.Location) (ret bucketWidth, ok bool) {
+	match := widthRegexp.FindStringSubmatch(strings.ToLower(s))
+	if match == nil {
+		return bucketWidth{}, false
+	}
+	unit, ok := widthUnits[match[2]]
+	if !ok {
+		return bucketWidth{}, false
+	}
+	multiple := int64(1)
+	if match[1] != "" {
+		var err error
+		multiple, err = strconv.ParseInt(match[1], 10, 64)
+		if err != nil || multiple < 1 || maxWidthMultiple < multiple {
+			return bucketWidth{}, false
+		}
+	}
+	if location != nil && unit.seconds == 24*60*60 {
+		return bucketWidth{calendar: Day, count: multiple, location: location}, true
+	}
+	if unit.seconds != 0 {
+		return bucketWidth{seconds: unit.seconds * Second(multiple)}, true
+	}
+	return bucketWidth{calendar: unit.calendar, count: multiple, location: location}, true
+}
+
+var zoneRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+\-/]*$`)
+
+// ParseLocation parses the tz parameter, an IANA time zone name. UTC is nil.
+func parseLocation(name string) (*time.Location, bool) {
+	// The name is put into the queries, and Local is not a zone of the database.
+	if !zoneRegexp.MatchString(name) || name == "Local" {
+		return nil, false
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	if location == time.UTC {
+		return nil, true
+	}
+	return location, true
+}
+
+// IntervalWidth is the width of one interval.
+func intervalWidth(interval Interval) bucketWidth {
+	desc := intervalMap[interval]
+	if desc.exact {
+		return bucketWidth{seconds: desc.minDuration}
+	}
+	return bucketWidth{calendar: interval, count: 1}
+}
+
+func (w bucketWidth) fixed() bool {
+	return w.seconds != 0
+}
+
+// Calendar widths other than weeks are counted in months.
+func (w bucketWidth) months() int64 {
+	switch w.calendar {
+	case Month:
+		return w.count
+	case Quarter:
+		return 3 * w.count
+	case Year:
+		return 12 * w.count
+	}
+	return 0
+}
+
+// Offset changes, like DST transitions, shorten or lengthen local calendar buckets.
+const zoneSlack = 60 * 60
+
+// Lower bound on the duration of a bucket.
+func (w bucketWidth) minDuration() Second {
+	if w.fixed() {
+		return w.seconds
+	}
+	ret := Second(w.count) * intervalMap[w.calendar].minDuration
+	if w.location != nil {
+		ret -= zoneSlack
+	}
+	return ret
+}
+
+// Upper bound on the duration of a bucket.
+func (w bucketWidth) maxDuration() Second {
+	if w.fixed() {
+		return w.seconds
+	}
+	ret := Second(w.count) * intervalMap[w.calendar].maxDuration
+	if w.location != nil {
+		ret += zoneSlack
+	}
+	return ret
+}
+
+func (w bucketWidth) zone() *time.Location {
+	if w.location == nil {
+		return time.UTC
+	}
+	return w.location
+}
+
+// ZoneName is the name of the time zone for the database.
+func (w bucketWidth) zoneName() string {
+	if w.location == nil {
+		return "UTC"
+	}
+	return w.location.String()
+}
+
+const (
+	weekSeconds = 7 * 24 * 60 * 60
+	// 1970-01-05, the first Monday after the epoch.
+	firstMondaySeconds = 4 * 24 * 60 * 60
+)
+
+func floorMod(a, b int64) int64 {
+	return ((a % b) + b) % b
+}
+
+// The calendar computations are on local dates, represented by their midnight in UTC.
+func localDate(s Second, location *time.Location) time.Time {
+	t := s.ToTime().In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// The start of the local date. Where midnight is skipped by a transition the day starts at the
+// end of the gap, like PostgreSQL takes the offset before the transition.
+func localMidnight(date time.Time, location *time.Location) Second {
+	t := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	if t.Day() != date.Day() {
+		// Time took the offset after the transition, which is still on the previous day.
+		_, offset := t.Zone()
+		return Second(date.Unix() - int64(offset))
+	}
+	return Second(t.Unix())
+}
+
+// Truncate returns the start of the bucket of the timestamp.
+func (w bucketWidth) truncate(s Second) Second {
+	if w.fixed() {
+		return s - Second(floorMod(s.ToI(), w.seconds.ToI()))
+	}
+	date := localDate(s, w.zone())
+	switch w.calendar {
+	case Day:
+		days := date.Unix() / (24 * 60 * 60)
+		date = date.AddDate(0, 0, -int(floorMod(days, w.count)))
+	case Week:
+		date = date.AddDate(0, 0, -int(floorMod(int64(date.Weekday())-1, 7)))
+		weeks := (date.Unix() - firstMondaySeconds) / weekSeconds
+		date = date.AddDate(0, 0, -7*int(floorMod(weeks, w.count)))
+	default:
+		month := int64(date.Year())*12 + int64(date.Month()) - 1
+		month -= floorMod(month, w.months())
+		date = time.Date(int(month/12), time.Month(month%12+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return localMidnight(date, w.zone())
+}
+
+// Next returns the start of the following bucket, start has to be the start of a bucket.
+func (w bucketWidth) next(start Second) Second {
+	if w.fixed() {
+		return start + w.seconds
+	}
+	date := localDate(start, w.zone())
+	switch w.calendar {
+	case Day:
+		date = date.AddDate(0, 0, int(w.count))
+	case Week:
+		date = date.AddDate(0, 0, 7*int(w.count))
+	default:
+		date = date.AddDate(0, int(w.months()), 0)
+	}
+	return localMidnight(date, w.zone())
+}
+
+// TruncateSQL returns the start of the bucket in seconds, like truncate, of a nanosecond column.
+func (w bucketWidth) truncateSQL(nanoColumn string) string {
+	if w.fixed() {
+		return fmt.Sprintf(`(%s / 1000000000 / %d * %d)::BIGINT`, nanoColumn, w.seconds, w.seconds)
+	}
+	// Truncated as a local timestamp, which is converted back to a time at the end.
+	local := fmt.Sprintf(`(to_timestamp(%s / 1000000000) AT TIME ZONE '%s')`, nanoColumn, w.zoneName())
+	unitName := intervalMap[w.calendar].name
+	start := fmt.Sprintf(`date_trunc('%s', %s)`, unitName, local)
+	if w.count != 1 {
+		switch w.calendar {
+		case Day:
+			start = fmt.Sprintf(
+				`%[1]s - (EXTRACT(EPOCH FROM %[1]s)::BIGINT / 86400 %% %[2]d) * INTERVAL '1 day'`,
+				start, w.count)
+		case Week:
+			start = fmt.Sprintf(
+				`%[1]s - ((EXTRACT(EPOCH FROM %[1]s)::BIGINT - %[2]d) / %[3]d %% %[4]d) * INTERVAL '1 week'`,
+				start, firstMondaySeconds, weekSeconds, w.count)
+		default:
+			start = fmt.Sprintf(
+				`date_trunc('month', %[1]s) - ((EXTRACT(YEAR FROM %[1]s) * 12 + EXTRACT(MONTH FROM %[1]s) - 1)::BIGINT %% %[2]d) * INTERVAL '1 month'`,
+				local, w.months())
+		}
+	}
+	return fmt.Sprintf(`EXTRACT(EPOCH FROM (%s) AT TIME ZONE '%s')::BIGINT`, start, w.zoneName())
+}
+
+// Aggregate returns the interval of the aggregates whose buckets fit in whole into these, the
+// widest one if there are more. The aggregates are in UTC, local calendar widths are left to
+// Buckets, which checks their boundaries.
+func (w bucketWidth) aggregate() (Interval, bool) {
+	if !w.fixed() {
+		return w.calendar, w.location == nil
+	}
+	for i := len(intervals) - 1; 0 <= i; i-- {
+		desc := intervals[i]
+		if desc.exact && w.seconds%desc.minDuration == 0 {
+			return desc.interval, true
+		}
+	}
+	return UndefinedInterval, false
+}
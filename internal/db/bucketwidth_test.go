@@ -0,0 +1,145 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBucketWidth(t *testing.T) {
+	for s, want := range map[string]bucketWidth{
+		"5min":    {seconds: 300},
+		"hour":    {seconds: 3600},
+		"4h":      {seconds: 4 * 3600},
+		"90min":   {seconds: 90 * 60},
+		"2D":      {seconds: 2 * 86400},
+		"week":    {calendar: Week, count: 1},
+		"2week":   {calendar: Week, count: 2},
+		"quarter": {calendar: Quarter, count: 1},
+		"6month":  {calendar: Month, count: 6},
+	} {
+		got, ok := parseBucketWidth(s, nil)
+		if !ok || got != want {
+			t.Errorf("parseBucketWidth(%q) = %v, %v, want %v", s, got, ok, want)
+		}
+	}
+	for _, s := range []string{"", "century", "0day", "-1day", "2 week", "week2", "1000000day"} {
+		if _, ok := parseBucketWidth(s, nil); ok {
+			t.Errorf("parseBucketWidth(%q) accepted", s)
+		}
+	}
+}
+
+func utcSecond(s string) Second {
+	t, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		panic(err)
+	}
+	return Second(t.Unix())
+}
+
+func TestBucketWidthTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		width string
+		time  string
+		start string
+		next  string
+	}{
+		{"7min", "2021-03-04 05:06:07", "2021-03-04 05:01:00", "2021-03-04 05:08:00"},
+		{"4h", "2021-03-04 05:06:07", "2021-03-04 04:00:00", "2021-03-04 08:00:00"},
+		{"week", "2021-03-04 05:06:07", "2021-03-01 00:00:00", "2021-03-08 00:00:00"},
+		{"2week", "2021-03-04 05:06:07", "2021-02-22 00:00:00", "2021-03-08 00:00:00"},
+		{"2week", "2021-03-10 00:00:00", "2021-03-08 00:00:00", "2021-03-22 00:00:00"},
+		{"month", "2021-03-04 05:06:07", "2021-03-01 00:00:00", "2021-04-01 00:00:00"},
+		{"5month", "2021-03-04 05:06:07", "2020-11-01 00:00:00", "2021-04-01 00:00:00"},
+		{"quarter", "2021-12-31 23:59:59", "2021-10-01 00:00:00", "2022-01-01 00:00:00"},
+		{"2year", "2021-03-04 05:06:07", "2020-01-01 00:00:00", "2022-01-01 00:00:00"},
+	} {
+		width, ok := parseBucketWidth(tc.width, nil)
+		if !ok {
+			t.Fatalf("parseBucketWidth(%q) failed", tc.width)
+		}
+		start := width.truncate(utcSecond(tc.time))
+		if start != utcSecond(tc.start) {
+			t.Errorf("%s: truncate(%s) = %s, want %s",
+				tc.width, tc.time, start.ToTime().UTC(), tc.start)
+		}
+		if next := width.next(start); next != utcSecond(tc.next) {
+			t.Errorf("%s: next(%s) = %s, want %s",
+				tc.width, tc.start, next.ToTime().UTC(), tc.next)
+		}
+	}
+}
+
+func TestBucketWidthAggregate(t *testing.T) {
+	for s, want := range map[string]string{
+		"5min":  "5min",
+		"15min": "5min",
+		"90min": "5min",
+		"2h":    "hour",
+		"7d":    "day",
+		"week":  "week",
+		"2week": "week",
+		"year":  "year",
+	} {
+		width, _ := parseBucketWidth(s, nil)
+		interval, ok := width.aggregate()
+		if !ok || intervalMap[interval].name != want {
+			t.Errorf("%s: aggregate %v, %v, want %s", s, interval, ok, want)
+		}
+	}
+	width, _ := parseBucketWidth("7min", nil)
+	if _, ok := width.aggregate(); ok {
+		t.Error("7min has no aggregate")
+	}
+}
+
+func TestBucketWidthTimeZone(t *testing.T) {
+	for _, tc := range []struct {
+		zone  string
+		width string
+		time  string
+		start string
+		next  string
+	}{
+		// Times are in UTC, New York is UTC-5 in winter, UTC-4 in summer.
+		{"America/New_York", "day", "2021-03-04 03:00:00", "2021-03-03 05:00:00", "2021-03-04 05:00:00"},
+		{"America/New_York", "month", "2021-03-01 03:00:00", "2021-02-01 05:00:00", "2021-03-01 05:00:00"},
+		// The day of the DST change is 23 hours long.
+		{"America/New_York", "day", "2021-03-14 12:00:00", "2021-03-14 05:00:00", "2021-03-15 04:00:00"},
+		{"America/New_York", "week", "2021-03-14 12:00:00", "2021-03-08 05:00:00", "2021-03-15 04:00:00"},
+		{"Asia/Kathmandu", "2d", "2021-03-04 12:00:00", "2021-03-03 18:15:00", "2021-03-05 18:15:00"},
+		// Midnight is skipped by the DST change, the day starts at 01:00.
+		{"America/Sao_Paulo", "day", "2018-11-04 12:00:00", "2018-11-04 03:00:00", "2018-11-05 02:00:00"},
+		// Hours stay fixed.
+		{"Asia/Kathmandu", "hour", "2021-03-04 12:30:00", "2021-03-04 12:00:00", "2021-03-04 13:00:00"},
+	} {
+		location, ok := parseLocation(tc.zone)
+		if !ok {
+			t.Fatalf("parseLocation(%q) failed", tc.zone)
+		}
+		width, ok := parseBucketWidth(tc.width, location)
+		if !ok {
+			t.Fatalf("parseBucketWidth(%q) failed", tc.width)
+		}
+		start := width.truncate(utcSecond(tc.time))
+		if start != utcSecond(tc.start) {
+			t.Errorf("%s %s: truncate(%s) = %s, want %s",
+				tc.zone, tc.width, tc.time, start.ToTime().UTC(), tc.start)
+		}
+		if next := width.next(start); next != utcSecond(tc.next) {
+			t.Errorf("%s %s: next(%s) = %s, want %s",
+				tc.zone, tc.width, tc.start, next.ToTime().UTC(), tc.next)
+		}
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	if location, ok := parseLocation("UTC"); !ok || location != nil {
+		t.Error("UTC should be nil")
+	}
+	for _, name := range []string{"Local", "Mars/Olympus", "Europe/Berlin'", "../etc/passwd"} {
+		if _, ok := parseLocation(name); ok {
+			t.Errorf("parseLocation(%q) accepted", name)
+		}
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// Query is the SQL client.
+// Query is the SQL client. It may go to a read replica, see replicas.go.
 var Query func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 
+// PrimaryQuery is Query on the primary, for reads which need the latest writes, like those of the
+// block writer, trim and partitioning.
+var PrimaryQuery func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+
 // Exec is the SQL client.
 var Exec func(query string, args ...interface{}) (sql.Result, error)
 
@@ -37,6 +41,9 @@ type TxDB struct {
 	sync.Mutex
 	db  *sql.Conn
 	txn *sql.Tx
+
+	// Rows for Insert, written on Commit.
+	inserts insertBuffer
 }
 
 func (txdb *TxDB) Begin() (err error) {
@@ -64,6 +71,15 @@ func (txdb *TxDB) Commit() (err error) {
 	if txdb.txn == nil {
 		log.Fatal().Msg("No txn open")
 	}
+	err = txdb.flush()
+	if err != nil {
+		log.Error().Err(err).Msg("Bulk insert failed")
+		if err2 := txdb.txn.Rollback(); err2 != nil {
+			log.Error().Err(err2).Msg("ROLLBACK failed")
+		}
+		txdb.txn = nil
+		return
+	}
 	err = txdb.txn.Commit()
 	if err != nil {
 		log.Error().Err(err).Msg("COMMIT failed")
@@ -103,16 +119,25 @@ type Config struct {
 
 	// -1 sets it to infinite
 	MaxOpenConns int `json:"max_open_conns"`
+
+	// Optional read replicas for Query, as PostgreSQL DSNs (e.g. "host=replica1 port=5432
+	// user=midgard password=password dbname=midgard sslmode=disable").
+	ReadReplicas []string `json:"read_replicas"`
+	// Replicas more blocks behind the primary are not queried, defaults to 10.
+	ReadReplicaMaxLag int64 `json:"read_replica_max_lag"`
+
+	// "timescale" (default) or "postgres" for plain PostgreSQL without TimescaleDB.
+	Storage string `json:"storage"`
+	// With the postgres storage, partition the tables by month of block_timestamp.
+	Partitioned bool `json:"partitioned"`
 }
 
-const (
-	ddlHashKey           = "ddl_hash"
-	aggregatesDdlHashKey = "aggregates_ddl_hash"
-)
+const aggregatesDdlHashKey = "aggregates_ddl_hash"
 
 type md5Hash [md5.Size]byte
 
-func Setup(config *Config) {
+// Open connects without touching the schema, and configures the storage mode.
+func Open(config *Config) *sql.DB {
 	dbObj, err := sql.Open("pgx",
 		fmt.Sprintf("user=%s dbname=%s sslmode=%s password=%s host=%s port=%d",
 			config.UserName, config.Database, config.Sslmode,
@@ -122,31 +147,80 @@ func Setup(config *Config) {
 	}
 
 	dbObj.SetMaxOpenConns(config.MaxOpenConns)
+	ConfigureStorage(config)
+	return dbObj
+}
+
+func Setup(config *Config) {
+	dbObj := Open(config)
 
 	dbConn, err := dbObj.Conn(context.Background())
 	if err != nil {
 		log.Fatal().Err(err).Msg("Opening a connection to PostgreSQL failed")
 	}
 
+	UseTxDB(dbConn)
+	Query = dbObj.QueryContext
+	PrimaryQuery = dbObj.QueryContext
+
+	theDB = dbObj
+
+	UpdateDDLsIfNeeded(dbObj)
+
+	setupReplicas(config)
+}
+
+// UseTxDB makes Exec, Insert, Begin and Commit work on conn, in transactions between Begin and
+// Commit.
+func UseTxDB(conn *sql.Conn) {
 	txdb := TxDB{
-		db:  dbConn,
+		db:  conn,
 		txn: nil,
 	}
 
 	Exec = txdb.Exec
-	Query = dbObj.QueryContext
+	Insert = txdb.Insert
 	Begin = txdb.Begin
 	Commit = txdb.Commit
+}
 
+// SetupReadOnly connects for Query only, without touching the schema, for tools which read.
+func SetupReadOnly(config *Config) {
+	dbObj := Open(config)
+	Query = dbObj.QueryContext
+	PrimaryQuery = dbObj.QueryContext
 	theDB = dbObj
+}
 
-	UpdateDDLsIfNeeded(dbObj)
+// Midgard and the tools which change the schema or the chain data take the writer lock, so they
+// never run at the same time. It's a PostgreSQL session advisory lock, held by writerLockConn
+// until the process exits.
+const writerLockKey = 0x4d494447415244 // "MIDGARD"
+
+var writerLockConn *sql.Conn
+
+// LockWriter takes the writer lock, before Setup. Fails when another process holds it.
+func LockWriter(config *Config) {
+	conn, err := Open(config).Conn(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Opening a connection to PostgreSQL failed")
+	}
+	var locked bool
+	err = conn.QueryRowContext(context.Background(),
+		"SELECT pg_try_advisory_lock($1)", writerLockKey).Scan(&locked)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Taking the writer lock failed")
+	}
+	if !locked {
+		log.Fatal().Msg("The database is in use by Midgard or one of its tools, stop it and retry")
+	}
+	writerLockConn = conn
 }
 
 func UpdateDDLsIfNeeded(dbObj *sql.DB) {
-	UpdateDDLIfNeeded(dbObj, "data", Ddl(), ddlHashKey)
-	// If 'data' DDL is updated the 'aggregates' DDL is automatically updated too, as
-	// the `constants` table is recreated with the 'data' DDL.
+	Migrate(dbObj)
+	// If the schema is recreated the 'aggregates' DDL is automatically updated too, as
+	// the `constants` table is recreated with it.
 	UpdateDDLIfNeeded(dbObj, "aggregates", AggregatesDdl(), aggregatesDdlHashKey)
 }
 
@@ -1,10 +1,11 @@
 package db
 
+// Ddl is the schema for the configured storage mode.
 func Ddl() string {
 	return `
--- version 11
-
-CREATE EXTENSION IF NOT EXISTS timescaledb CASCADE;
+-- version 17
+-- The schema as of the last destructive migration, see migrations.go.
+` + storageExtensionDdl() + `
 
 ----------
 -- Clean up
@@ -53,31 +54,7 @@ CREATE TABLE block_log (
 CREATE INDEX ON block_log (timestamp DESC);
 
 
--- For hypertables with an integer 'time' dimension (as opposed to TIMESTAMPTZ),
--- TimescaleDB requires an 'integer_now' function to be set to use continuous aggregates.
--- We use the following function, 'current_nano', as the 'integer_now' function
--- for all of our hypertables.
---
--- This function is only comes into play if one uses TimescaleDB's automatic refresh policies
--- for continuous aggregates. As we trigger refreshes directly from Midgard, what this
--- function does is basically irrelevant, so we choose to return the most directly
--- corresponding notion of 'now'.
---
--- An alternative approach would be to get the latest block timestamp from 'block_log' or some
--- other table and use TimescaleDB's automatic refresh policies. (The downside is that it gets
--- harder to control, if for example we want to suspend refreshing, etc.)
-CREATE OR REPLACE FUNCTION current_nano() RETURNS BIGINT
-LANGUAGE SQL STABLE AS $$
-    SELECT CAST(1000000000 * EXTRACT(EPOCH FROM CURRENT_TIMESTAMP) AS BIGINT)
-$$;
-
-CREATE PROCEDURE setup_hypertable(t regclass)
-LANGUAGE SQL
-AS $$
-    SELECT create_hypertable(t, 'block_timestamp', chunk_time_interval => 86400000000000);
-    SELECT set_integer_now_func(t, 'current_nano');
-$$;
-
+` + storageFunctionsDdl() + `
 
 -- The standard PostgreSQL 'date_trunc(field, timestamp)' function,
 --  but takes and returns 'nanos from epoch'
@@ -101,9 +78,22 @@ CREATE TABLE block_pool_depths (
 CALL setup_hypertable('block_pool_depths');
 CREATE INDEX ON block_pool_depths (pool, block_timestamp DESC);
 
+-- Sparse table for pool units, filled like block_pool_depths.
+CREATE TABLE block_pool_units (
+	pool				VARCHAR(60) NOT NULL,
+	units				BIGINT NOT NULL,
+	block_timestamp		BIGINT NOT NULL
+);
+
+CALL setup_hypertable('block_pool_units');
+CREATE INDEX ON block_pool_units (pool, block_timestamp DESC);
+
 
 CREATE TABLE active_vault_events (
 	add_asgard_addr		VARCHAR(90) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -118,8 +108,17 @@ CREATE TABLE add_events (
 	asset			VARCHAR(60),
 	asset_E8		BIGINT NOT NULL,
 	memo			TEXT NOT NULL,
+	memo_action		VARCHAR(20),
+	memo_asset		VARCHAR(60),
+	memo_addr		VARCHAR(90),
+	memo_limit_E8	BIGINT,
+	memo_affiliate_addr	VARCHAR(90),
+	memo_affiliate_BP	BIGINT,
 	rune_E8			BIGINT NOT NULL,
 	pool			VARCHAR(60) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -131,6 +130,9 @@ CREATE TABLE asgard_fund_yggdrasil_events (
 	asset			VARCHAR(60) NOT NULL,
 	asset_E8		BIGINT NOT NULL,
 	vault_key		VARCHAR(90) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -147,6 +149,9 @@ CREATE TABLE bond_events (
 	memo			TEXT,
 	bond_type		VARCHAR(32) NOT NULL,
 	E8			    BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -158,6 +163,9 @@ CREATE TABLE errata_events (
 	asset			VARCHAR(60) NOT NULL,
 	asset_E8		BIGINT NOT NULL,
 	rune_E8			BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -169,6 +177,9 @@ CREATE TABLE fee_events (
 	asset			VARCHAR(60) NOT NULL,
 	asset_E8		BIGINT NOT NULL,
 	pool_deduct		BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -180,6 +191,9 @@ CREATE TABLE gas_events (
 	asset_E8		BIGINT NOT NULL,
 	rune_E8			BIGINT NOT NULL,
 	tx_count		BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -188,6 +202,9 @@ CALL setup_hypertable('gas_events');
 
 CREATE TABLE inactive_vault_events (
 	add_asgard_addr		VARCHAR(90) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -197,6 +214,9 @@ CALL setup_hypertable('inactive_vault_events');
 CREATE TABLE set_mimir_events (
 	key			        VARCHAR(63) NOT NULL,
 	value			    VARCHAR(127) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -206,6 +226,9 @@ CALL setup_hypertable('set_mimir_events');
 CREATE TABLE message_events (
 	from_addr		    VARCHAR(90) NOT NULL,
 	action			    VARCHAR(31) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -214,6 +237,9 @@ CALL setup_hypertable('message_events');
 
 CREATE TABLE new_node_events (
 	node_addr		    VARCHAR(48) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -229,6 +255,9 @@ CREATE TABLE outbound_events (
 	asset_E8		BIGINT NOT NULL,
 	memo			TEXT NOT NULL,
 	in_tx			VARCHAR(64) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -238,6 +267,9 @@ CALL setup_hypertable('outbound_events');
 CREATE TABLE pool_events (
 	asset			VARCHAR(60) NOT NULL,
 	status			VARCHAR(64) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -256,6 +288,9 @@ CREATE TABLE refund_events (
 	memo			TEXT,
 	code			BIGINT NOT NULL,
 	reason			TEXT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -272,6 +307,9 @@ CREATE TABLE reserve_events (
 	memo			TEXT NOT NULL,
 	addr			VARCHAR(48) NOT NULL,
 	E8			    BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -280,6 +318,9 @@ CALL setup_hypertable('reserve_events');
 
 CREATE TABLE rewards_events (
 	bond_E8			    BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -289,6 +330,9 @@ CALL setup_hypertable('rewards_events');
 CREATE TABLE rewards_event_entries (
 	pool			    VARCHAR(60) NOT NULL,
 	rune_E8			    BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -298,6 +342,9 @@ CALL setup_hypertable('rewards_event_entries');
 CREATE TABLE set_ip_address_events (
 	node_addr		    VARCHAR(44) NOT NULL,
 	ip_addr			    VARCHAR(45) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -309,6 +356,9 @@ CREATE TABLE set_node_keys_events (
 	secp256k1	    	VARCHAR(90) NOT NULL,
 	ed25519			    VARCHAR(90) NOT NULL,
 	validator_consensus	VARCHAR(90) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -318,6 +368,9 @@ CALL setup_hypertable('set_node_keys_events');
 CREATE TABLE set_version_events (
 	node_addr		    VARCHAR(44) NOT NULL,
 	version			    VARCHAR(127) NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -328,12 +381,29 @@ CREATE TABLE slash_amounts (
 	pool			    VARCHAR(60) NOT NULL,
 	asset			    VARCHAR(60) NOT NULL,
 	asset_E8		    BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
 CALL setup_hypertable('slash_amounts');
 
 
+CREATE TABLE slash_points_events (
+	node_addr		    VARCHAR(90) NOT NULL,
+	slash_points	    BIGINT NOT NULL,
+	reason			    TEXT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
+	block_timestamp		BIGINT NOT NULL
+);
+
+CALL setup_hypertable('slash_points_events');
+CREATE INDEX ON slash_points_events (node_addr, block_timestamp DESC);
+
+
 CREATE TABLE stake_events (
 	pool			VARCHAR(60) NOT NULL,
 	asset_tx		VARCHAR(64),
@@ -344,6 +414,9 @@ CREATE TABLE stake_events (
 	rune_tx			VARCHAR(64),
 	rune_addr		VARCHAR(90),
 	rune_E8			BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -360,6 +433,9 @@ CREATE TABLE pending_liquidity_events (
 	rune_addr		VARCHAR(90),
 	rune_E8			BIGINT NOT NULL,
 	pending_type	VARCHAR(10) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -375,15 +451,25 @@ CREATE TABLE swap_events (
 	to_asset		    VARCHAR(60) NOT NULL,
 	to_E8			    BIGINT NOT NULL,
 	memo			    TEXT NOT NULL,
+	memo_action			VARCHAR(20),
+	memo_asset			VARCHAR(60),
+	memo_addr			VARCHAR(90),
+	memo_limit_E8		BIGINT,
+	memo_affiliate_addr	VARCHAR(90),
+	memo_affiliate_BP	BIGINT,
 	pool			    VARCHAR(60) NOT NULL,
 	to_E8_min		    BIGINT NOT NULL,
 	swap_slip_BP	    BIGINT NOT NULL,
 	liq_fee_E8		    BIGINT NOT NULL,
 	liq_fee_in_rune_E8	BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
 CALL setup_hypertable('swap_events');
+CREATE INDEX ON swap_events (memo_affiliate_addr, block_timestamp) WHERE memo_affiliate_addr IS NOT NULL;
 
 
 CREATE TABLE switch_events (
@@ -391,6 +477,9 @@ CREATE TABLE switch_events (
 	to_addr			    VARCHAR(90) NOT NULL,
 	burn_asset		    VARCHAR(60) NOT NULL,
 	burn_E8			    BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
 	block_timestamp		BIGINT NOT NULL
 );
 
@@ -402,12 +491,39 @@ CREATE TABLE transfer_events (
 	to_addr			VARCHAR(90) NOT NULL,
 	asset			VARCHAR(60) NOT NULL,
 	amount_E8		BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
 CALL setup_hypertable('transfer_events');
 
 
+CREATE TABLE tss_keygen_events (
+	pub_key			    VARCHAR(90) NOT NULL,
+	median_duration_ms	BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
+	block_timestamp		BIGINT NOT NULL
+);
+
+CALL setup_hypertable('tss_keygen_events');
+
+
+CREATE TABLE tss_keysign_events (
+	tx				    VARCHAR(64) NOT NULL,
+	median_duration_ms	BIGINT NOT NULL,
+	block_height		BIGINT NOT NULL,
+	tx_index			INTEGER NOT NULL,
+	event_index			INTEGER NOT NULL,
+	block_timestamp		BIGINT NOT NULL
+);
+
+CALL setup_hypertable('tss_keysign_events');
+
+
 CREATE TABLE unstake_events (
 	tx			    VARCHAR(64) NOT NULL,
 	chain			VARCHAR(8) NOT NULL,
@@ -418,11 +534,20 @@ CREATE TABLE unstake_events (
 	emit_asset_E8	BIGINT NOT NULL,
 	emit_rune_E8	BIGINT NOT NULL,
 	memo			TEXT NOT NULL,
+	memo_action		VARCHAR(20),
+	memo_asset		VARCHAR(60),
+	memo_addr		VARCHAR(90),
+	memo_limit_E8	BIGINT,
+	memo_affiliate_addr	VARCHAR(90),
+	memo_affiliate_BP	BIGINT,
 	pool			VARCHAR(60) NOT NULL,
 	stake_units		BIGINT NOT NULL,
 	basis_points	BIGINT NOT NULL,
 	asymmetry		DOUBLE PRECISION NOT NULL,
 	imp_loss_protection_E8 BIGINT NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -433,6 +558,9 @@ CREATE TABLE update_node_account_status_events (
 	node_addr		VARCHAR(90) NOT NULL,
 	former			VARCHAR(31) NOT NULL,
 	current			VARCHAR(31) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -443,6 +571,9 @@ CREATE TABLE validator_request_leave_events (
 	tx			    VARCHAR(64) NOT NULL,
 	from_addr		VARCHAR(90) NOT NULL,
 	node_addr		VARCHAR(90) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
@@ -455,9 +586,28 @@ CREATE TABLE pool_balance_change_events (
 	asset_amt       BIGINT NOT NULL,
 	asset_add       BOOLEAN NOT NULL,
 	reason          VARCHAR(100) NOT NULL,
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
 	block_timestamp	BIGINT NOT NULL
 );
 
 CALL setup_hypertable('pool_balance_change_events');
+
+
+-- Events which could not be recorded, see record.QuarantinedEvent.
+CREATE TABLE quarantined_events (
+	block_height	BIGINT NOT NULL,
+	tx_index		INTEGER NOT NULL,
+	event_index		INTEGER NOT NULL,
+	type			VARCHAR(127) NOT NULL,
+	attributes		JSONB NOT NULL,
+	error			TEXT NOT NULL,
+	recorded		BOOLEAN NOT NULL,
+	block_timestamp	BIGINT NOT NULL
+);
+
+CALL setup_hypertable('quarantined_events');
+CREATE INDEX ON quarantined_events (block_height, event_index);
 `
 }
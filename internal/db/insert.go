@@ -0,0 +1,148 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PostgreSQL accepts at most 65535 parameters per statement.
+const maxInsertParams = 65535
+
+// Insert adds a row with values for the comma separated columns to table.
+//
+// Use between Begin and Commit. The rows are buffered per table and written by Commit with
+// multi-row INSERTs. When any of them fails Commit fails, and nothing of the transaction is
+// written.
+//
+// Before Setup, as in the tests, the row is written right away with Exec and failures panic.
+var Insert = func(table, columns string, values ...interface{}) {
+	if err := insertRows(Exec, table, columns, len(values), values); err != nil {
+		log.Panic().Err(err).Msg("Insert failed")
+	}
+}
+
+// Rows buffers the values of the rows for one table and column list.
+type rows struct {
+	table, columns string
+	width          int
+	values         []interface{}
+}
+
+// insertBuffer has the rows of one transaction, in the order of the first row per table.
+type insertBuffer struct {
+	byKey map[string]*rows
+	order []*rows
+	// First failure of add, reported by flush.
+	err error
+}
+
+func (b *insertBuffer) add(table, columns string, values []interface{}) {
+	if b.err != nil {
+		return
+	}
+	key := table + " (" + columns + ")"
+	r, ok := b.byKey[key]
+	if !ok {
+		width := strings.Count(columns, ",") + 1
+		r = &rows{table: table, columns: columns, width: width}
+		if b.byKey == nil {
+			b.byKey = make(map[string]*rows)
+		}
+		b.byKey[key] = r
+		b.order = append(b.order, r)
+	}
+	if len(values) != r.width {
+		b.err = fmt.Errorf("insert into %s: %d values for %d columns", key, len(values), r.width)
+		return
+	}
+	r.values = append(r.values, values...)
+}
+
+func (b *insertBuffer) reset() {
+	b.err = nil
+	for _, r := range b.order {
+		// keep the capacity for the next block
+		r.values = r.values[:0]
+	}
+}
+
+// InsertRows writes the values, width per row, with as few statements as possible.
+func insertRows(exec func(query string, args ...interface{}) (sql.Result, error),
+	table, columns string, width int, values []interface{}) error {
+	if width == 0 || len(values)%width != 0 {
+		return fmt.Errorf("insert into %s: %d values for %d columns", table, len(values), width)
+	}
+	perStatement := maxInsertParams / width * width
+	for len(values) != 0 {
+		n := len(values)
+		if perStatement < n {
+			n = perStatement
+		}
+		_, err := exec(insertQuery(table, columns, width, n/width), values[:n]...)
+		if err != nil {
+			return fmt.Errorf("insert into %s: %w", table, err)
+		}
+		values = values[n:]
+	}
+	return nil
+}
+
+func insertQuery(table, columns string, width, rowCount int) string {
+	var buf bytes.Buffer
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(table)
+	buf.WriteString(" (")
+	buf.WriteString(columns)
+	buf.WriteString(") VALUES ")
+	param := 1
+	for i := 0; i < rowCount; i++ {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('(')
+		for j := 0; j < width; j++ {
+			if j != 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteByte('$')
+			buf.WriteString(strconv.Itoa(param))
+			param++
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// Flush writes the buffered rows within the transaction, one table at a time.
+// The caller must hold the lock.
+func (txdb *TxDB) flush() error {
+	defer txdb.inserts.reset()
+	if txdb.inserts.err != nil {
+		return txdb.inserts.err
+	}
+	for _, r := range txdb.inserts.order {
+		if len(r.values) == 0 {
+			continue
+		}
+		err := insertRows(txdb.txn.Exec, r.table, r.columns, r.width, r.values)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert buffers the row for Commit.
+func (txdb *TxDB) Insert(table, columns string, values ...interface{}) {
+	txdb.Lock()
+	defer txdb.Unlock()
+	if txdb.txn == nil {
+		log.Fatal().Msgf("Insert into %s outside of a txn", table)
+	}
+	txdb.inserts.add(table, columns, values)
+}
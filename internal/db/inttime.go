@@ -80,7 +80,7 @@ func SetFirstBlockTimestamp(n Nano) {
 
 func LoadFirstBlockFromDB(ctx context.Context) {
 	q := `select timestamp, hash from block_log where height = 1`
-	rows, err := Query(ctx, q)
+	rows, err := PrimaryQuery(ctx, q)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to query for first timestamp")
 	}
@@ -0,0 +1,200 @@
+package db
+
+// Schema changes are migrations, applied once each in the order of their version. The applied
+// version is kept in the constants table.
+//
+// Additive migrations (e.g. a new index, table or nullable column) run on the live database.
+// Destructive migrations recreate the schema from Ddl(), after which the chain is synced from
+// the start again. Ddl() is the schema as of the last destructive migration, so a fresh database
+// gets Ddl() and the additive migrations after it.
+//
+// To change the schema, append a migration to the list. Only when the change can't be made to
+// existing data, mark it destructive and fold all changes since the previous one into Ddl().
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Migration is a schema change.
+type Migration struct {
+	Version     int
+	Description string
+	// Destructive migrations recreate the schema from Ddl() and have no SQL.
+	Destructive bool
+	// SQL of additive migrations, executed in one transaction.
+	SQL string
+}
+
+var migrations = []Migration{
+	{Version: 17, Description: "baseline schema", Destructive: true},
+	{
+		Version:     18,
+		Description: "nano_trunc in UTC, like the bucket timestamps",
+		SQL: `
+			CREATE OR REPLACE FUNCTION nano_trunc(field TEXT, ts BIGINT) RETURNS BIGINT
+			LANGUAGE SQL IMMUTABLE AS $$
+				SELECT CAST(1000000000 * EXTRACT(EPOCH FROM
+					date_trunc(field, to_timestamp(ts / 1000000000) AT TIME ZONE 'UTC')) AS BIGINT)
+			$$;`,
+	},
+	{
+		Version:     19,
+		Description: "drop quarantined_events.recorded, failed inserts fail the block instead",
+		SQL:         `ALTER TABLE quarantined_events DROP COLUMN recorded;`,
+	},
+	{
+		Version:     20,
+		Description: "index for the affiliate fee lookup of outbounds by in_tx",
+		SQL:         `CREATE INDEX ON outbound_events (in_tx);`,
+	},
+}
+
+const schemaVersionKey = "schema_version"
+
+// Migrations returns all migrations in order of version.
+func Migrations() []Migration {
+	return migrations
+}
+
+// LatestVersion is the version the schema gets with all migrations applied.
+func LatestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// BaseVersion is the version of the last destructive migration, which is the version of Ddl().
+func baseVersion() int {
+	for i := len(migrations) - 1; 0 <= i; i-- {
+		if migrations[i].Destructive {
+			return migrations[i].Version
+		}
+	}
+	panic("no destructive migration for Ddl()")
+}
+
+// PendingMigrations returns the migrations not applied at version.
+func PendingMigrations(version int) []Migration {
+	var ret []Migration
+	for _, m := range migrations {
+		if version < m.Version {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+// SchemaVersion returns the applied migration version, 0 for a database without schema
+// or from before the versioning.
+func SchemaVersion(ctx context.Context, dbObj *sql.DB) (int, error) {
+	var exists bool
+	err := dbObj.QueryRowContext(ctx, `SELECT EXISTS (
+		SELECT * FROM pg_tables WHERE tablename = 'constants' AND schemaname = 'midgard'
+	)`).Scan(&exists)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var value []byte
+	err = dbObj.QueryRowContext(ctx,
+		`SELECT value FROM midgard.constants WHERE key = $1`, schemaVersionKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	version, err := strconv.Atoi(string(value))
+	if err != nil {
+		return 0, fmt.Errorf("malformed %s %q in constants table: %w", schemaVersionKey, value, err)
+	}
+	return version, nil
+}
+
+// StoredStorage returns the storage the schema was created for. Schemas from before the
+// storage modes are TimescaleDB.
+func storedStorage(ctx context.Context, dbObj *sql.DB) (string, error) {
+	var value []byte
+	err := dbObj.QueryRowContext(ctx,
+		`SELECT value FROM midgard.constants WHERE key = $1`, storageKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return StorageTimescale, nil
+	}
+	return string(value), err
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func setSchemaVersion(e execer, version int) error {
+	_, err := e.Exec(`INSERT INTO midgard.constants (key, value) VALUES ($1, $2)
+					  ON CONFLICT (key) DO UPDATE SET value = $2`,
+		schemaVersionKey, []byte(strconv.Itoa(version)))
+	return err
+}
+
+// Migrate applies the pending migrations. Failures are fatal.
+func Migrate(dbObj *sql.DB) {
+	version, err := SchemaVersion(context.Background(), dbObj)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Schema version lookup failed")
+	}
+	if LatestVersion() < version {
+		log.Fatal().Msgf("Schema version %d is newer than the latest migration %d of this build",
+			version, LatestVersion())
+	}
+
+	if version != 0 {
+		stored, err := storedStorage(context.Background(), dbObj)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Storage lookup failed")
+		}
+		if stored != storageName() {
+			log.Info().Msgf("Schema storage %q, configured %q", stored, storageName())
+			version = 0
+		}
+	}
+
+	if version < baseVersion() {
+		log.Info().Msgf("Schema version %d, recreating the schema at version %d, the chain is synced again",
+			version, baseVersion())
+		if _, err := dbObj.Exec(Ddl()); err != nil {
+			log.Fatal().Err(err).Msg("Applying the ddl failed, exiting")
+		}
+		version = baseVersion()
+		if err := setSchemaVersion(dbObj, version); err != nil {
+			log.Fatal().Err(err).Msg("Updating 'constants' table failed, exiting")
+		}
+		_, err := dbObj.Exec(`INSERT INTO midgard.constants (key, value) VALUES ($1, $2)`,
+			storageKey, []byte(storageName()))
+		if err != nil {
+			log.Fatal().Err(err).Msg("Updating 'constants' table failed, exiting")
+		}
+	}
+
+	for _, m := range PendingMigrations(version) {
+		log.Info().Msgf("Applying migration %d: %s", m.Version, m.Description)
+		if err := applyMigration(dbObj, m); err != nil {
+			log.Fatal().Err(err).Msgf("Migration %d failed, exiting", m.Version)
+		}
+	}
+}
+
+func applyMigration(dbObj *sql.DB, m Migration) error {
+	tx, err := dbObj.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(m.SQL); err != nil {
+		return err
+	}
+	if err := setSchemaVersion(tx, m.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	if !migrations[0].Destructive {
+		t.Errorf("first migration %d is not destructive", migrations[0].Version)
+	}
+	for i, m := range migrations {
+		if 0 < i && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d follows %d", m.Version, migrations[i-1].Version)
+		}
+		if m.Destructive != (m.SQL == "") {
+			t.Errorf("migration %d: destructive ones recreate Ddl(), others need SQL", m.Version)
+		}
+	}
+}
+
+func TestDdlVersion(t *testing.T) {
+	want := fmt.Sprintf("-- version %d\n", baseVersion())
+	if !strings.Contains(Ddl(), want) {
+		t.Errorf("Ddl() lacks %q, the version of the last destructive migration", want)
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	if got := PendingMigrations(LatestVersion()); len(got) != 0 {
+		t.Errorf("got %d pending migrations at the latest version", len(got))
+	}
+	if got := PendingMigrations(0); len(got) != len(migrations) {
+		t.Errorf("got %d pending migrations for an empty database, want all %d",
+			len(got), len(migrations))
+	}
+}
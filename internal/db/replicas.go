@@ -0,0 +1,153 @@
+package db
+
+// Read replicas take the Query load off the primary, which has to keep up with the chain.
+// Queries go round-robin to the healthy replicas, and to the primary when there are none.
+// A replica is healthy when it answers and is at most Config.ReadReplicaMaxLag blocks behind
+// the primary. Exec, PrimaryQuery and the block writes always use the primary.
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/thorchain/midgard/internal/util/jobs"
+)
+
+const (
+	replicaCheckInterval = 5 * time.Second
+	replicaCheckTimeout  = 2 * time.Second
+	defaultReplicaMaxLag = 10
+)
+
+type replica struct {
+	db *sql.DB
+	// Set with atomics by the health check.
+	healthy int32
+	lag     int64
+}
+
+var (
+	replicas      []*replica
+	replicaMaxLag int64
+	replicaNext   uint32
+)
+
+// ReplicaHealth is the state of a read replica as of the last health check.
+type ReplicaHealth struct {
+	Healthy bool
+	// Blocks behind the primary, -1 when unknown.
+	HeightLag int64
+}
+
+// ReplicasHealth returns the state of the read replicas in the configured order.
+func ReplicasHealth() []ReplicaHealth {
+	ret := make([]ReplicaHealth, len(replicas))
+	for i, r := range replicas {
+		ret[i] = ReplicaHealth{
+			Healthy:   atomic.LoadInt32(&r.healthy) != 0,
+			HeightLag: atomic.LoadInt64(&r.lag),
+		}
+	}
+	return ret
+}
+
+// SetupReplicas routes Query to the read replicas of the config. Replicas start unhealthy
+// until the first health check.
+func setupReplicas(config *Config) {
+	replicaMaxLag = config.ReadReplicaMaxLag
+	if replicaMaxLag == 0 {
+		replicaMaxLag = defaultReplicaMaxLag
+	}
+	for _, dsn := range config.ReadReplicas {
+		dbObj, err := sql.Open("pgx", dsn)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Exit on PostgreSQL read replica client instantiation")
+		}
+		dbObj.SetMaxOpenConns(config.MaxOpenConns)
+		replicas = append(replicas, &replica{db: dbObj, lag: -1})
+	}
+	if len(replicas) == 0 {
+		return
+	}
+	log.Info().Msgf("Routing queries to %d read replicas", len(replicas))
+	Query = replicaQuery
+}
+
+func replicaQuery(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	n := uint32(len(replicas))
+	start := atomic.AddUint32(&replicaNext, 1)
+	for i := uint32(0); i < n; i++ {
+		r := replicas[(start+i)%n]
+		if atomic.LoadInt32(&r.healthy) == 0 {
+			continue
+		}
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err == nil || ctx.Err() != nil {
+			return rows, err
+		}
+		// the next health check decides whether it's back
+		log.Warn().Err(err).Msg("Read replica query failed, falling back to the primary")
+		atomic.StoreInt32(&r.healthy, 0)
+		break
+	}
+	return PrimaryQuery(ctx, query, args...)
+}
+
+const lastHeightQuery = "SELECT COALESCE(MAX(height), 0) FROM block_log"
+
+func checkReplicas(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	var primaryHeight int64
+	if err := theDB.QueryRowContext(ctx, lastHeightQuery).Scan(&primaryHeight); err != nil {
+		log.Error().Err(err).Msg("Primary height lookup for the read replica check failed")
+		return
+	}
+
+	for i, r := range replicas {
+		var height int64
+		if err := r.db.QueryRowContext(ctx, lastHeightQuery).Scan(&height); err != nil {
+			if atomic.SwapInt32(&r.healthy, 0) != 0 {
+				log.Warn().Err(err).Msgf("Read replica %d is down", i)
+			}
+			atomic.StoreInt64(&r.lag, -1)
+			continue
+		}
+		lag := primaryHeight - height
+		if lag < 0 {
+			// the replica may be ahead between the two queries
+			lag = 0
+		}
+		atomic.StoreInt64(&r.lag, lag)
+
+		var healthy int32
+		if lag <= replicaMaxLag {
+			healthy = 1
+		}
+		if atomic.SwapInt32(&r.healthy, healthy) != healthy {
+			log.Info().Msgf("Read replica %d healthy: %t, %d blocks behind", i, healthy != 0, lag)
+		}
+	}
+}
+
+// StartReplicaHealthCheck returns nil without read replicas.
+func StartReplicaHealthCheck(ctx context.Context) *jobs.Job {
+	if len(replicas) == 0 {
+		return nil
+	}
+	log.Info().Msg("Starting read replica health check job")
+	job := jobs.Start("ReplicaHealthCheck", func() {
+		for {
+			if ctx.Err() != nil {
+				log.Info().Msg("Shutdown read replica health check job")
+				return
+			}
+			checkReplicas(ctx)
+			jobs.Sleep(ctx, replicaCheckInterval)
+		}
+	})
+	return &job
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+// NameDriver answers every query with the data source name, or fails for "down".
+type nameDriver struct{}
+
+type nameConn struct{ name string }
+
+type nameStmt struct{ name string }
+
+type nameRows struct {
+	name string
+	done bool
+}
+
+func init() {
+	sql.Register("name", nameDriver{})
+}
+
+func (nameDriver) Open(name string) (driver.Conn, error) { return nameConn{name}, nil }
+
+func (c nameConn) Prepare(query string) (driver.Stmt, error) { return nameStmt{c.name}, nil }
+func (nameConn) Close() error                                { return nil }
+func (nameConn) Begin() (driver.Tx, error)                   { return nil, errors.New("no txn") }
+
+func (nameStmt) Close() error  { return nil }
+func (nameStmt) NumInput() int { return -1 }
+func (nameStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("no exec")
+}
+func (s nameStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.name == "down" {
+		return nil, errors.New("connection refused")
+	}
+	return &nameRows{name: s.name}, nil
+}
+
+func (*nameRows) Columns() []string { return []string{"name"} }
+func (*nameRows) Close() error      { return nil }
+func (r *nameRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.name
+	return nil
+}
+
+func openName(t *testing.T, name string) *sql.DB {
+	dbObj, err := sql.Open("name", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbObj
+}
+
+// QueriedName returns the name of the database which answered.
+func queriedName(t *testing.T,
+	query func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)) string {
+	rows, err := query(context.Background(), "SELECT name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var name string
+	if !rows.Next() {
+		t.Fatal("no rows")
+	}
+	if err := rows.Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestReplicaRouting(t *testing.T) {
+	savedReplicas, savedPrimaryQuery := replicas, PrimaryQuery
+	defer func() {
+		replicas, PrimaryQuery = savedReplicas, savedPrimaryQuery
+	}()
+
+	PrimaryQuery = openName(t, "primary").QueryContext
+	replicas = []*replica{
+		{db: openName(t, "replica0"), lag: -1},
+		{db: openName(t, "replica1"), lag: -1},
+		{db: openName(t, "down"), lag: -1},
+	}
+
+	if got := queriedName(t, replicaQuery); got != "primary" {
+		t.Errorf("without healthy replicas got %s, want primary", got)
+	}
+
+	atomic.StoreInt32(&replicas[1].healthy, 1)
+	for i := 0; i < 3; i++ {
+		if got := queriedName(t, replicaQuery); got != "replica1" {
+			t.Errorf("with replica1 healthy got %s", got)
+		}
+	}
+
+	atomic.StoreInt32(&replicas[0].healthy, 1)
+	seen := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		seen[queriedName(t, replicaQuery)] = true
+	}
+	if !seen["replica0"] || !seen["replica1"] || len(seen) != 2 {
+		t.Errorf("round-robin over both healthy replicas got %v", seen)
+	}
+
+	// A failing replica falls back to the primary and is unhealthy until the next check.
+	atomic.StoreInt32(&replicas[0].healthy, 0)
+	atomic.StoreInt32(&replicas[1].healthy, 0)
+	atomic.StoreInt32(&replicas[2].healthy, 1)
+	if got := queriedName(t, replicaQuery); got != "primary" {
+		t.Errorf("with the replica down got %s, want primary", got)
+	}
+	if atomic.LoadInt32(&replicas[2].healthy) != 0 {
+		t.Error("failing replica still healthy")
+	}
+
+	atomic.StoreInt32(&replicas[0].healthy, 1)
+	if got := queriedName(t, PrimaryQuery); got != "primary" {
+		t.Errorf("PrimaryQuery got %s", got)
+	}
+}
@@ -0,0 +1,437 @@
+package db
+
+// A snapshot is the database up to a height, for bootstrapping a new Midgard without syncing
+// the chain from the start.
+//
+// The archive is a gzipped tar:
+//   - manifest.json, the SnapshotManifest
+//   - <table>.copy for every table, in the text format of COPY
+//   - SHA256SUMS of the table files, in the format of sha256sum
+//
+// Aggregates are not in the snapshot, Midgard refreshes them after the import.
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	snapshotManifestName = "manifest.json"
+	snapshotSumsName     = "SHA256SUMS"
+	snapshotTableSuffix  = ".copy"
+)
+
+// SnapshotManifest describes the contents of a snapshot.
+type SnapshotManifest struct {
+	ChainID       string    `json:"chainID"`
+	Height        int64     `json:"height"`
+	Timestamp     Nano      `json:"timestamp"`
+	FirstBlock    Nano      `json:"firstBlock"`
+	SchemaVersion int       `json:"schemaVersion"`
+	Tables        []string  `json:"tables"`
+	Created       time.Time `json:"created"`
+}
+
+// ExportSnapshot writes the tables up to and including the height to w, as of one consistent
+// read of the database. Height 0 is the last block. The schema has to be at the latest version
+// of this build, as imports only accept their own version.
+func ExportSnapshot(ctx context.Context, w io.Writer, height int64) (*SnapshotManifest, error) {
+	version, err := SchemaVersion(ctx, theDB)
+	if err != nil {
+		return nil, err
+	}
+	if version != LatestVersion() {
+		return nil, fmt.Errorf("database has schema version %d, this build %d", version, LatestVersion())
+	}
+
+	var manifest *SnapshotManifest
+	err = withPgxConn(ctx, func(conn *pgx.Conn) error {
+		tx, err := conn.BeginTx(ctx, pgx.TxOptions{
+			IsoLevel:   pgx.RepeatableRead,
+			AccessMode: pgx.ReadOnly,
+		})
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		if height == 0 {
+			if err := tx.QueryRow(ctx, lastHeightQuery).Scan(&height); err != nil {
+				return err
+			}
+		}
+		manifest = &SnapshotManifest{
+			Height:        height,
+			SchemaVersion: version,
+			Created:       time.Now().UTC(),
+		}
+		var timestamp, firstTimestamp int64
+		var firstHash []byte
+		err = tx.QueryRow(ctx, `
+			SELECT
+				COALESCE((SELECT timestamp FROM block_log WHERE height = $1), 0),
+				timestamp,
+				hash
+			FROM block_log WHERE height = 1`, height).Scan(
+			&timestamp, &firstTimestamp, &firstHash)
+		if err == pgx.ErrNoRows {
+			return errors.New("no blocks in the database")
+		}
+		if err != nil {
+			return fmt.Errorf("block %d lookup: %w", height, err)
+		}
+		if timestamp == 0 {
+			return fmt.Errorf("block %d not in the database", height)
+		}
+		manifest.Timestamp = Nano(timestamp)
+		manifest.FirstBlock = Nano(firstTimestamp)
+		SetFirstBlochHash(string(firstHash))
+		manifest.ChainID = ChainID()
+
+		columns, err := snapshotTables(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for table := range columns {
+			manifest.Tables = append(manifest.Tables, table)
+		}
+		sort.Strings(manifest.Tables)
+
+		gz := gzip.NewWriter(w)
+		tw := tar.NewWriter(gz)
+		if err := writeSnapshotManifest(tw, manifest); err != nil {
+			return err
+		}
+		sums := map[string]string{}
+		for _, table := range manifest.Tables {
+			q := fmt.Sprintf("COPY (SELECT * FROM %s%s) TO STDOUT",
+				table, snapshotFilter(columns[table], manifest.Height, manifest.Timestamp))
+			sum, err := writeSnapshotTable(ctx, tw, tx.Conn(), table, q)
+			if err != nil {
+				return fmt.Errorf("export of %s: %w", table, err)
+			}
+			sums[table+snapshotTableSuffix] = sum
+			log.Info().Msgf("Exported %s", table)
+		}
+		sumsFile := []byte(formatSnapshotSums(sums))
+		if err := writeTarFile(tw, snapshotSumsName, sumsFile); err != nil {
+			return err
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gz.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ImportSnapshot restores a snapshot into a database without blocks. The schema version has to
+// be the one of the snapshot. Nothing is imported unless the checksums and the chain ID match.
+func ImportSnapshot(ctx context.Context, r io.Reader) (*SnapshotManifest, error) {
+	version, err := SchemaVersion(ctx, theDB)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if header.Name != snapshotManifestName {
+		return nil, fmt.Errorf("snapshot starts with %q, want %q", header.Name, snapshotManifestName)
+	}
+	var manifest SnapshotManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("malformed snapshot manifest: %w", err)
+	}
+	if manifest.SchemaVersion != version {
+		return nil, fmt.Errorf("snapshot has schema version %d, database has %d",
+			manifest.SchemaVersion, version)
+	}
+	var blocks int64
+	if err := theDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM block_log").Scan(&blocks); err != nil {
+		return nil, err
+	}
+	if blocks != 0 {
+		return nil, fmt.Errorf("database has %d blocks already, import needs an empty one", blocks)
+	}
+	first := manifest.FirstBlock.ToSecond().ToTime().UTC()
+	last := manifest.Timestamp.ToSecond().ToTime()
+	month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for ; !month.After(last); month = month.AddDate(0, 1, 0) {
+		if err := EnsurePartitions(ctx, month); err != nil {
+			return nil, err
+		}
+	}
+
+	err = withPgxConn(ctx, func(conn *pgx.Conn) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		tables := map[string]bool{}
+		for _, table := range manifest.Tables {
+			tables[table] = true
+		}
+		sums := map[string]string{}
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				return fmt.Errorf("snapshot ends without %s: %w", snapshotSumsName, err)
+			}
+			if header.Name == snapshotSumsName {
+				b, err := ioutil.ReadAll(tr)
+				if err != nil {
+					return err
+				}
+				if err := verifySnapshotSums(string(b), sums, manifest.Tables); err != nil {
+					return err
+				}
+				break
+			}
+
+			table := strings.TrimSuffix(header.Name, snapshotTableSuffix)
+			if !tables[table] || header.Name == table {
+				return fmt.Errorf("unknown file %q in snapshot", header.Name)
+			}
+			h := sha256.New()
+			if err := importSnapshotTable(ctx, tx, table, io.TeeReader(tr, h)); err != nil {
+				return fmt.Errorf("import of %s: %w", table, err)
+			}
+			sums[header.Name] = hexSum(h)
+			log.Info().Msgf("Imported %s", table)
+		}
+
+		var firstHash []byte
+		var height int64
+		err = tx.QueryRow(ctx, `
+			SELECT hash, (SELECT MAX(height) FROM block_log)
+			FROM block_log WHERE height = 1`).Scan(&firstHash, &height)
+		if err != nil {
+			return fmt.Errorf("first block lookup: %w", err)
+		}
+		SetFirstBlochHash(string(firstHash))
+		if ChainID() != manifest.ChainID {
+			return fmt.Errorf("imported chain ID %s, snapshot manifest has %s",
+				ChainID(), manifest.ChainID)
+		}
+		if height != manifest.Height {
+			return fmt.Errorf("imported blocks up to height %d, snapshot manifest has %d",
+				height, manifest.Height)
+		}
+		return tx.Commit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// WithPgxConn runs f on a connection of its own, for what database/sql doesn't do, like COPY.
+func withPgxConn(ctx context.Context, f func(*pgx.Conn) error) error {
+	conn, err := theDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn interface{}) error {
+		return f(driverConn.(*stdlib.Conn).Conn())
+	})
+}
+
+// SnapshotTables returns the columns of the tables in the midgard schema. Partitions are part
+// of their table.
+func snapshotTables(ctx context.Context, tx pgx.Tx) (tableMap, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname, a.attname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid
+		WHERE n.nspname = 'midgard' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+			AND 0 < a.attnum AND NOT a.attisdropped`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := tableMap{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		if _, ok := ret[table]; !ok {
+			ret[table] = map[string]bool{}
+		}
+		ret[table][column] = true
+	}
+	return ret, rows.Err()
+}
+
+// SnapshotFilter selects the rows up to the block, by the same columns as DeleteBlocksFrom.
+func snapshotFilter(columns map[string]bool, height int64, timestamp Nano) string {
+	switch {
+	case columns["block_height"]:
+		return fmt.Sprintf(" WHERE block_height <= %d", height)
+	case columns["block_timestamp"]:
+		return fmt.Sprintf(" WHERE block_timestamp <= %d", timestamp)
+	case columns["height"]:
+		return fmt.Sprintf(" WHERE height <= %d", height)
+	}
+	return ""
+}
+
+func writeSnapshotManifest(tw *tar.Writer, manifest *SnapshotManifest) error {
+	b, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeTarFile(tw, snapshotManifestName, append(b, '\n'))
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(content)
+	return err
+}
+
+// WriteSnapshotTable adds the output of the COPY query to the archive, and returns its SHA-256.
+// A tar entry needs its size upfront, so the table goes through a temporary file.
+func writeSnapshotTable(ctx context.Context, tw *tar.Writer, conn *pgx.Conn, table, q string) (
+	string, error) {
+	tmp, err := ioutil.TempFile("", "midgard-snapshot-"+table+"-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	buf := bufio.NewWriter(io.MultiWriter(tmp, h))
+	if _, err := conn.PgConn().CopyTo(ctx, buf, q); err != nil {
+		return "", err
+	}
+	if err := buf.Flush(); err != nil {
+		return "", err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    table + snapshotTableSuffix,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(tw, tmp); err != nil {
+		return "", err
+	}
+	return hexSum(h), nil
+}
+
+// ImportSnapshotTable copies the rows into the table. The constants of the schema itself stay,
+// the others are added.
+func importSnapshotTable(ctx context.Context, tx pgx.Tx, table string, r io.Reader) error {
+	if table != "constants" {
+		_, err := tx.Conn().PgConn().CopyFrom(ctx, r, fmt.Sprintf("COPY %s FROM STDIN", table))
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `CREATE TEMPORARY TABLE snapshot_constants (LIKE constants) ON COMMIT DROP`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Conn().PgConn().CopyFrom(ctx, r, "COPY snapshot_constants FROM STDIN")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO constants SELECT * FROM snapshot_constants
+		ON CONFLICT (key) DO NOTHING`)
+	return err
+}
+
+func hexSum(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FormatSnapshotSums returns the lines of sha256sum, sorted by file name.
+func formatSnapshotSums(sums map[string]string) string {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", sums[name], name)
+	}
+	return b.String()
+}
+
+// VerifySnapshotSums checks that every table was read with the checksum of the sums file.
+func verifySnapshotSums(sumsFile string, read map[string]string, tables []string) error {
+	want := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(sumsFile), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("malformed line %q in %s", line, snapshotSumsName)
+		}
+		want[fields[1]] = fields[0]
+	}
+	for _, table := range tables {
+		name := table + snapshotTableSuffix
+		sum, ok := read[name]
+		if !ok {
+			return fmt.Errorf("%s missing from snapshot", name)
+		}
+		if want[name] != sum {
+			return fmt.Errorf("checksum mismatch of %s: %s, want %q", name, sum, want[name])
+		}
+	}
+	return nil
+}
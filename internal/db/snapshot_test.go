@@ -0,0 +1,48 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestSnapshotSums(t *testing.T) {
+	read := map[string]string{
+		"swap_events.copy": "bb",
+		"block_log.copy":   "aa",
+	}
+	sums := formatSnapshotSums(read)
+	if want := "aa  block_log.copy\nbb  swap_events.copy\n"; sums != want {
+		t.Errorf("got sums %q, want %q", sums, want)
+	}
+	tables := []string{"block_log", "swap_events"}
+	if err := verifySnapshotSums(sums, read, tables); err != nil {
+		t.Error(err)
+	}
+
+	read["swap_events.copy"] = "cc"
+	if err := verifySnapshotSums(sums, read, tables); err == nil {
+		t.Error("checksum mismatch not detected")
+	}
+	delete(read, "swap_events.copy")
+	if err := verifySnapshotSums(sums, read, tables); err == nil {
+		t.Error("missing table not detected")
+	}
+	if err := verifySnapshotSums("aa block_log.copy extra", read, tables[:1]); err == nil {
+		t.Error("malformed line not detected")
+	}
+}
+
+func TestSnapshotFilter(t *testing.T) {
+	for _, c := range []struct {
+		columns map[string]bool
+		want    string
+	}{
+		{map[string]bool{"block_height": true, "block_timestamp": true}, " WHERE block_height <= 7"},
+		{map[string]bool{"block_timestamp": true}, " WHERE block_timestamp <= 100"},
+		{map[string]bool{"height": true, "timestamp": true}, " WHERE height <= 7"},
+		{map[string]bool{"key": true}, ""},
+	} {
+		if got := snapshotFilter(c.columns, 7, 100); got != c.want {
+			t.Errorf("columns %v: got %q, want %q", c.columns, got, c.want)
+		}
+	}
+}
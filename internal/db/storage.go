@@ -0,0 +1,318 @@
+package db
+
+// Midgard runs on TimescaleDB by default. The plain PostgreSQL storage stands in for it:
+//   - Hypertables are ordinary tables. When partitioned, they are partitioned by the month of
+//     block_timestamp, with a default partition for rows outside of the months created by
+//     EnsurePartitions.
+//   - time_bucket and last are SQL functions.
+//   - Continuous aggregates are rollup tables up to a watermark, which refreshAggregates moves
+//     forward by adding the buckets in between. Rows after the watermark are aggregated at query
+//     time, like the real-time aggregates of TimescaleDB. Aggregates TimescaleDB can't maintain continuously (e.g. with
+//     joins) are kept this way with TimescaleDB too.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Storage modes
+const (
+	StorageTimescale = "timescale"
+	StoragePostgres  = "postgres"
+)
+
+var (
+	storage     = StorageTimescale
+	partitioned bool
+)
+
+const storageKey = "storage"
+
+// ConfigureStorage selects the storage mode of Ddl and AggregatesDdl. Invalid settings are
+// fatal.
+func ConfigureStorage(config *Config) {
+	switch config.Storage {
+	case "", StorageTimescale:
+		if config.Partitioned {
+			log.Fatal().Msg("Partitioned tables need the postgres storage, TimescaleDB has its own")
+		}
+		storage = StorageTimescale
+	case StoragePostgres:
+		storage = StoragePostgres
+	default:
+		log.Fatal().Msgf("Unknown storage %q, should be %q or %q",
+			config.Storage, StorageTimescale, StoragePostgres)
+	}
+	partitioned = config.Partitioned
+}
+
+// StorageName identifies the schema variant, a change needs the schema recreated.
+func storageName() string {
+	if partitioned {
+		return storage + " partitioned"
+	}
+	return storage
+}
+
+func storageExtensionDdl() string {
+	if storage == StorageTimescale {
+		return `
+CREATE EXTENSION IF NOT EXISTS timescaledb CASCADE;
+`
+	}
+	return ""
+}
+
+func storageFunctionsDdl() string {
+	if storage == StorageTimescale {
+		return timescaleFunctionsDdl
+	}
+	setup := plainSetupHypertable
+	if partitioned {
+		setup = partitionedSetupHypertable
+	}
+	return postgresFunctionsDdl + setup
+}
+
+const timescaleFunctionsDdl = `
+-- For hypertables with an integer 'time' dimension (as opposed to TIMESTAMPTZ),
+-- TimescaleDB requires an 'integer_now' function to be set to use continuous aggregates.
+-- We use the following function, 'current_nano', as the 'integer_now' function
+-- for all of our hypertables.
+--
+-- This function is only comes into play if one uses TimescaleDB's automatic refresh policies
+-- for continuous aggregates. As we trigger refreshes directly from Midgard, what this
+-- function does is basically irrelevant, so we choose to return the most directly
+-- corresponding notion of 'now'.
+--
+-- An alternative approach would be to get the latest block timestamp from 'block_log' or some
+-- other table and use TimescaleDB's automatic refresh policies. (The downside is that it gets
+-- harder to control, if for example we want to suspend refreshing, etc.)
+CREATE OR REPLACE FUNCTION current_nano() RETURNS BIGINT
+LANGUAGE SQL STABLE AS $$
+    SELECT CAST(1000000000 * EXTRACT(EPOCH FROM CURRENT_TIMESTAMP) AS BIGINT)
+$$;
+
+CREATE PROCEDURE setup_hypertable(t regclass)
+LANGUAGE SQL
+AS $$
+    SELECT create_hypertable(t, 'block_timestamp', chunk_time_interval => 86400000000000);
+    SELECT set_integer_now_func(t, 'current_nano');
+$$;
+`
+
+const postgresFunctionsDdl = `
+-- Stand-ins for the TimescaleDB functions in use.
+
+CREATE FUNCTION time_bucket(width BIGINT, ts BIGINT) RETURNS BIGINT
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT ts - ((ts % width) + width) % width
+$$;
+
+-- last(value, time) is the value with the highest time.
+CREATE FUNCTION last_step(state BIGINT[], value BIGINT, t BIGINT) RETURNS BIGINT[]
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT CASE WHEN state IS NULL OR state[2] <= t THEN ARRAY[value, t] ELSE state END
+$$;
+CREATE FUNCTION last_final(state BIGINT[]) RETURNS BIGINT
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT state[1]
+$$;
+CREATE AGGREGATE last(BIGINT, BIGINT) (SFUNC = last_step, STYPE = BIGINT[], FINALFUNC = last_final);
+
+CREATE FUNCTION last_step(state TEXT[], value TEXT, t BIGINT) RETURNS TEXT[]
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT CASE WHEN state IS NULL OR state[2]::BIGINT <= t THEN ARRAY[value, t::TEXT] ELSE state END
+$$;
+CREATE FUNCTION last_final(state TEXT[]) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE AS $$
+    SELECT state[1]
+$$;
+CREATE AGGREGATE last(TEXT, BIGINT) (SFUNC = last_step, STYPE = TEXT[], FINALFUNC = last_final);
+`
+
+// Indexed on block_timestamp, like TimescaleDB does for hypertables.
+const plainSetupHypertable = `
+CREATE PROCEDURE setup_hypertable(t regclass)
+LANGUAGE plpgsql
+AS $$ BEGIN
+    EXECUTE format('CREATE INDEX ON %s (block_timestamp DESC)', t);
+END $$;
+`
+
+// The table is replaced by a partitioned one with the same columns, while it's empty still.
+const partitionedSetupHypertable = `
+CREATE PROCEDURE setup_hypertable(t regclass)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    name TEXT := t::TEXT;
+BEGIN
+    EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING ALL) PARTITION BY RANGE (block_timestamp)',
+        name || '_partitioned', name);
+    EXECUTE format('DROP TABLE %I', name);
+    EXECUTE format('ALTER TABLE %I RENAME TO %I', name || '_partitioned', name);
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I DEFAULT', name || '_default', name);
+    EXECUTE format('CREATE INDEX ON %I (block_timestamp DESC)', name);
+END $$;
+`
+
+// PartitionedMonths are the months EnsurePartitions handled already, by their start in
+// nanoseconds.
+var partitionedMonths sync.Map
+
+// EnsurePartitions creates the partitions of the month of the timestamp, unless done already.
+// Without partitioning it does nothing. Call it before writing a block.
+//
+// A month which has rows in the default partition already (e.g. from a trim and resync) keeps
+// them there, as the partition can't be created.
+func EnsurePartitions(ctx context.Context, timestamp time.Time) error {
+	if !partitioned {
+		return nil
+	}
+	t := timestamp.UTC()
+	month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := month.UnixNano()
+	until := month.AddDate(0, 1, 0).UnixNano()
+	if _, done := partitionedMonths.Load(from); done {
+		return nil
+	}
+
+	tables, err := partitionedTables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf(`DO $$ BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM %[1]s_default WHERE %[3]d <= block_timestamp AND block_timestamp < %[4]d)
+			THEN
+				CREATE TABLE IF NOT EXISTS %[1]s_%[2]s PARTITION OF %[1]s
+				FOR VALUES FROM (%[3]d) TO (%[4]d);
+			END IF;
+		END $$`, table, month.Format("200601"), from, until)
+		if _, err := Exec(q); err != nil {
+			return fmt.Errorf("partition %s of %s: %w", month.Format("2006-01"), table, err)
+		}
+	}
+	partitionedMonths.Store(from, true)
+	return nil
+}
+
+func partitionedTables(ctx context.Context) ([]string, error) {
+	rows, err := PrimaryQuery(ctx, `
+		SELECT c.relname
+		FROM pg_partitioned_table p
+		JOIN pg_class c ON c.oid = p.partrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'midgard'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		ret = append(ret, table)
+	}
+	return ret, rows.Err()
+}
+
+// The materialized part of an aggregate which isn't continuous is a rollup table with the
+// buckets before its watermark. Refreshes only add the buckets up to the new watermark.
+const watermarksDdl = `
+		CREATE TABLE midgard_agg.watermarks (
+			aggregate	TEXT NOT NULL,
+			watermark	BIGINT NOT NULL,
+			PRIMARY KEY (aggregate)
+		);
+`
+
+func aggregateWatermark(name string) string {
+	return `(SELECT COALESCE(MAX(watermark), 0) FROM midgard_agg.watermarks
+						WHERE aggregate = '` + name + `')`
+}
+
+func aggregateBucketField(bucket Second) string {
+	return fmt.Sprintf("time_bucket('%d', block_timestamp)", bucket.ToNano())
+}
+
+// ExactAggregateDdl creates the aggregate of a constant size bucket, a continuous aggregate of
+// TimescaleDB or a rollup table with a watermark.
+func exactAggregateDdl(name string, lowerQuery string, bucket Second, continuous bool) string {
+	bucketField := aggregateBucketField(bucket)
+	if continuous {
+		q := strings.TrimSpace(fmt.Sprintf(lowerQuery, bucketField, "TRUE"))
+		return `
+					CREATE MATERIALIZED VIEW midgard_agg.` + name + `
+					WITH (timescaledb.continuous) AS
+					` + q + `
+					WITH NO DATA;
+				`
+	}
+
+	rollup := strings.TrimSpace(fmt.Sprintf(lowerQuery, bucketField, "FALSE"))
+	realTime := strings.TrimSpace(fmt.Sprintf(lowerQuery, bucketField,
+		aggregateWatermark(name)+" <= block_timestamp"))
+	return `
+					CREATE TABLE midgard_agg.` + name + `_materialized AS
+					` + rollup + `
+					WITH NO DATA;
+					CREATE INDEX ON midgard_agg.` + name + `_materialized (bucket_start);
+
+					CREATE VIEW midgard_agg.` + name + ` AS
+					SELECT * FROM midgard_agg.` + name + `_materialized
+					UNION ALL
+					(` + realTime + `);
+				`
+}
+
+// RefreshExactAggregate materializes the buckets up to refreshEnd.
+func refreshExactAggregate(ctx context.Context, name string, lowerQuery string, bucket Second,
+	refreshEnd Nano, continuous bool) error {
+	if continuous {
+		_, err := theDB.ExecContext(ctx, fmt.Sprintf(
+			"CALL refresh_continuous_aggregate('midgard_agg.%s', NULL, '%d')", name, refreshEnd))
+		return err
+	}
+
+	// whole buckets only, the rest is aggregated at query time
+	width := bucket.ToNano()
+	watermark := refreshEnd - refreshEnd%width
+	tx, err := theDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var oldWatermark Nano
+	err = tx.QueryRowContext(ctx, `SELECT watermark FROM midgard_agg.watermarks
+		WHERE aggregate = $1 FOR UPDATE`, name).Scan(&oldWatermark)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if watermark <= oldWatermark {
+		return nil
+	}
+	rollup := fmt.Sprintf(lowerQuery, aggregateBucketField(bucket),
+		"$1 <= block_timestamp AND block_timestamp < $2")
+	_, err = tx.ExecContext(ctx, "INSERT INTO midgard_agg."+name+"_materialized "+rollup,
+		oldWatermark, watermark)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO midgard_agg.watermarks (aggregate, watermark)
+		VALUES ($1, $2) ON CONFLICT (aggregate) DO UPDATE SET watermark = $2`, name, watermark)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,6 +1,7 @@
 package testdb
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	"github.com/stretchr/testify/require"
+	"gitlab.com/thorchain/midgard/internal/db"
 	"gitlab.com/thorchain/midgard/internal/fetch/chain"
 	"gitlab.com/thorchain/midgard/internal/fetch/record"
 	"gitlab.com/thorchain/midgard/internal/timeseries"
@@ -26,19 +28,31 @@ type FakeEvent interface {
 func (bc *blockCreator) NewBlock(t *testing.T, timeStr string, events ...FakeEvent) {
 	bc.lastHeight++
 
+	block := FakeBlock(bc.lastHeight, timeStr, events...)
+	err := db.EnsurePartitions(context.Background(), block.Time)
+	require.NoError(t, err)
+
+	bc.demux.Block(block)
+	err = timeseries.CommitBlock(block.Height, block.Time, block.Hash)
+	require.NoError(t, err)
+}
+
+// FakeBlock returns a block with the events as end block events. Besides NewBlock, it can
+// feed a fakenode.Node for tests through the Tendermint RPC.
+func FakeBlock(height int64, timeStr string, events ...FakeEvent) chain.Block {
 	block := chain.Block{
-		Height:  bc.lastHeight,
+		Height:  height,
 		Time:    StrToSec(timeStr).ToTime(),
-		Hash:    []byte(fmt.Sprintf("hash%d", bc.lastHeight)),
-		Results: &coretypes.ResultBlockResults{}}
+		Hash:    []byte(fmt.Sprintf("hash%d", height)),
+		Results: &coretypes.ResultBlockResults{Height: height}}
+	if 1 < height {
+		block.ParentHash = []byte(fmt.Sprintf("hash%d", height-1))
+	}
 
 	for _, event := range events {
 		block.Results.EndBlockEvents = append(block.Results.EndBlockEvents, event.ToTendermint())
 	}
-
-	bc.demux.Block(block)
-	err := timeseries.CommitBlock(block.Height, block.Time, block.Hash)
-	require.NoError(t, err)
+	return block
 }
 
 func toAttributes(attrs map[string]string) (ret []abci.EventAttribute) {
@@ -65,12 +79,13 @@ type Swap struct {
 	ToAddress    string
 	TxID         string
 	PriceTarget  int64
+	Memo         string
 }
 
 func (x Swap) ToTendermint() abci.Event {
 	return abci.Event{Type: "swap", Attributes: toAttributes(map[string]string{
 		"pool":                  x.Pool,
-		"memo":                  "doesntmatter",
+		"memo":                  withDefaultStr(x.Memo, "doesntmatter"),
 		"coin":                  x.Coin,
 		"emit_asset":            x.EmitAsset,
 		"from":                  withDefaultStr(x.FromAddress, "addressfrom"),
@@ -84,6 +99,25 @@ func (x Swap) ToTendermint() abci.Event {
 	})}
 }
 
+type Outbound struct {
+	TxID      string
+	InTxID    string
+	Coin      string
+	ToAddress string
+}
+
+func (x Outbound) ToTendermint() abci.Event {
+	return abci.Event{Type: "outbound", Attributes: toAttributes(map[string]string{
+		"id":       withDefaultStr(x.TxID, "outtxid"),
+		"in_tx_id": x.InTxID,
+		"chain":    "chain",
+		"from":     "addressfrom",
+		"to":       withDefaultStr(x.ToAddress, "addressto"),
+		"coin":     x.Coin,
+		"memo":     "OUT:" + x.InTxID,
+	})}
+}
+
 type AddLiquidity struct {
 	Pool         string
 	AssetAmount  int64
@@ -92,6 +126,7 @@ type AddLiquidity struct {
 	RuneAddress  string
 	RuneTxID     string
 	AssetTxID    string
+	Units        int64
 }
 
 func assetTxIdKey(pool string) string {
@@ -108,7 +143,7 @@ func (x AddLiquidity) ToTendermint() abci.Event {
 	assetIdKey := assetTxIdKey(x.Pool)
 	return abci.Event{Type: "add_liquidity", Attributes: toAttributes(map[string]string{
 		"pool":                     x.Pool,
-		"liquidity_provider_units": "1",
+		"liquidity_provider_units": unitsWithDefault(x.Units),
 		"rune_address":             withDefaultStr(x.RuneAddress, "runeAddress"),
 		"rune_amount":              util.IntStr(x.RuneAmount),
 		"asset_amount":             util.IntStr(x.AssetAmount),
@@ -118,6 +153,13 @@ func (x AddLiquidity) ToTendermint() abci.Event {
 	})}
 }
 
+func unitsWithDefault(units int64) string {
+	if units == 0 {
+		return "1"
+	}
+	return util.IntStr(units)
+}
+
 type PendingTypeEnum int
 
 const (
@@ -167,13 +209,14 @@ type Withdraw struct {
 	EmitRune          int64
 	ImpLossProtection int64
 	ToAddress         string
+	Units             int64
 }
 
 func (x Withdraw) ToTendermint() abci.Event {
 	return abci.Event{Type: "withdraw", Attributes: toAttributes(map[string]string{
 		"pool":                     x.Pool,
 		"coin":                     withDefaultStr(x.Coin, "0 THOR.RUNE"),
-		"liquidity_provider_units": "1",
+		"liquidity_provider_units": unitsWithDefault(x.Units),
 		"basis_points":             "1",
 		"asymmetry":                "0.000000000000000000",
 		"emit_rune":                util.IntStr(x.EmitRune),
@@ -227,6 +270,54 @@ func (x Fee) ToTendermint() abci.Event {
 	})}
 }
 
+type SlashPoints struct {
+	NodeAddress string
+	Points      int64
+	Reason      string
+}
+
+func (x SlashPoints) ToTendermint() abci.Event {
+	return abci.Event{Type: "slash_points", Attributes: toAttributes(map[string]string{
+		"node_address": x.NodeAddress,
+		"slash_points": util.IntStr(x.Points),
+		"reason":       withDefaultStr(x.Reason, "fail_to_observe"),
+	})}
+}
+
+type TSSKeygen struct {
+	PubKey           string
+	MedianDurationMs int64
+}
+
+func (x TSSKeygen) ToTendermint() abci.Event {
+	return abci.Event{Type: "tss_keygen", Attributes: toAttributes(map[string]string{
+		"pubkey":             x.PubKey,
+		"median_duration_ms": util.IntStr(x.MedianDurationMs),
+	})}
+}
+
+type TSSKeysign struct {
+	TxID             string
+	MedianDurationMs int64
+}
+
+func (x TSSKeysign) ToTendermint() abci.Event {
+	return abci.Event{Type: "tss_keysign", Attributes: toAttributes(map[string]string{
+		"txid":               withDefaultStr(x.TxID, "txid"),
+		"median_duration_ms": util.IntStr(x.MedianDurationMs),
+	})}
+}
+
+// RawEvent has the type and the attributes as is, e.g. for unknown or malformed events.
+type RawEvent struct {
+	Type       string
+	Attributes map[string]string
+}
+
+func (x RawEvent) ToTendermint() abci.Event {
+	return abci.Event{Type: x.Type, Attributes: toAttributes(x.Attributes)}
+}
+
 func toCoin(asset string, assetE8 int64) string {
 	return fmt.Sprintf("%d", assetE8) + " " + asset
 }
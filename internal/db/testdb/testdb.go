@@ -29,6 +29,10 @@ import (
 var (
 	testDBQuery func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	testDBExec  func(query string, args ...interface{}) (sql.Result, error)
+	// Insert writing right away, see SetupTestTxDB for the buffered one.
+	testDBInsert = db.Insert
+	testDBObj    *sql.DB
+	testDBConn   *sql.Conn
 )
 
 func init() {
@@ -44,7 +48,13 @@ func init() {
 
 	testDBQuery = dbObj.QueryContext
 	testDBExec = dbObj.Exec
+	testDBObj = dbObj
 
+	// The e2e tests run with DB_STORAGE=postgres against plain PostgreSQL too.
+	db.ConfigureStorage(&db.Config{
+		Storage:     getEnvVariable("DB_STORAGE", db.StorageTimescale),
+		Partitioned: getEnvVariable("DB_PARTITIONED", "false") == "true",
+	})
 	db.UpdateDDLsIfNeeded(dbObj)
 }
 
@@ -54,16 +64,34 @@ func SetupTestDB(t *testing.T) {
 	}
 	db.Exec = testDBExec
 	db.Query = testDBQuery
+	db.PrimaryQuery = testDBQuery
+	db.Insert = testDBInsert
+}
+
+// SetupTestTxDB is SetupTestDB with the transactions of the block writer, so db.Insert buffers
+// the rows between db.Begin and db.Commit.
+func SetupTestTxDB(t *testing.T) {
+	SetupTestDB(t)
+	if testDBConn == nil {
+		conn, err := testDBObj.Conn(context.Background())
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to connect to PostgreSQL")
+		}
+		testDBConn = conn
+	}
+	db.UseTxDB(testDBConn)
 }
 
 func DeleteTables(t *testing.T) {
 	MustExec(t, "DELETE FROM block_log")
 	MustExec(t, "DELETE FROM block_pool_depths")
+	MustExec(t, "DELETE FROM block_pool_units")
 	MustExec(t, "DELETE FROM stake_events")
 	MustExec(t, "DELETE FROM pending_liquidity_events")
 	MustExec(t, "DELETE FROM unstake_events")
 	MustExec(t, "DELETE FROM switch_events")
 	MustExec(t, "DELETE FROM swap_events")
+	MustExec(t, "DELETE FROM outbound_events")
 	MustExec(t, "DELETE FROM fee_events")
 	MustExec(t, "DELETE FROM rewards_events")
 	MustExec(t, "DELETE FROM rewards_event_entries")
@@ -72,6 +100,10 @@ func DeleteTables(t *testing.T) {
 	MustExec(t, "DELETE FROM update_node_account_status_events")
 	MustExec(t, "DELETE FROM active_vault_events")
 	MustExec(t, "DELETE FROM set_mimir_events")
+	MustExec(t, "DELETE FROM slash_points_events")
+	MustExec(t, "DELETE FROM tss_keygen_events")
+	MustExec(t, "DELETE FROM tss_keysign_events")
+	MustExec(t, "DELETE FROM quarantined_events")
 }
 
 func InitTest(t *testing.T) {
@@ -202,6 +234,13 @@ func CallFail(t *testing.T, url string, msg ...string) {
 	}
 }
 
+// The Insert*Event functions write rows directly, without a block. They get height 0, and the
+// transaction and event index 0.
+const (
+	rawPosition       = "block_height, tx_index, event_index"
+	rawPositionValues = "0, 0, 0"
+)
+
 type FakeBond struct {
 	Tx             string
 	Chain          string
@@ -217,8 +256,8 @@ type FakeBond struct {
 
 func InsertBondEvent(t *testing.T, fake FakeBond) {
 	const insertq = `INSERT INTO bond_events ` +
-		`(tx, chain, from_addr, to_addr, asset, asset_E8, memo, bond_type, E8, block_timestamp) ` +
-		`VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8, $9, $10)`
+		`(tx, chain, from_addr, to_addr, asset, asset_E8, memo, bond_type, E8, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8, $9, $10, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 
@@ -242,8 +281,8 @@ type FakeStake struct {
 
 func InsertStakeEvent(t *testing.T, fake FakeStake) {
 	const insertq = `INSERT INTO stake_events ` +
-		`(pool, asset_tx, asset_chain, asset_addr, asset_E8, rune_tx, rune_addr, rune_E8, stake_units, block_timestamp) ` +
-		`VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8, $9, $10)`
+		`(pool, asset_tx, asset_chain, asset_addr, asset_E8, rune_tx, rune_addr, rune_E8, stake_units, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8, $9, $10, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 
@@ -270,8 +309,8 @@ func InsertUnstakeEvent(t *testing.T, fake FakeUnstake) {
 		INSERT INTO unstake_events
 			(tx, chain, from_addr, to_addr, asset, asset_E8, emit_asset_E8, emit_rune_E8,
 				memo, pool, stake_units, basis_points, asymmetry, imp_loss_protection_E8,
-				block_timestamp)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+				block_timestamp, ` + rawPosition + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 	MustExec(t, insertq,
@@ -291,8 +330,8 @@ type FakeFee struct {
 
 func InsertFeeEvent(t *testing.T, fake FakeFee) {
 	const insertq = `INSERT INTO fee_events ` +
-		`(tx, asset, asset_e8, pool_deduct, block_timestamp) ` +
-		`VALUES ($1, $2, $3, $4, $5)`
+		`(tx, asset, asset_e8, pool_deduct, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, $4, $5, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 	MustExec(t, insertq, fake.Tx, fake.Asset, fake.AssetE8, fake.PoolDeduct, timestamp)
@@ -317,8 +356,8 @@ type FakeSwap struct {
 func InsertSwapEvent(t *testing.T, fake FakeSwap) {
 	const insertq = `INSERT INTO swap_events ` +
 		`(tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP,
-			liq_fee_E8, liq_fee_in_rune_E8, block_timestamp) ` +
-		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+			liq_fee_E8, liq_fee_in_rune_E8, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 	var toAsset string
@@ -342,8 +381,8 @@ type FakeSwitch struct {
 
 func InsertSwitchEvent(t *testing.T, fake FakeSwitch) {
 	const insertq = `INSERT INTO switch_events ` +
-		`(from_addr, to_addr, burn_asset, burn_e8, block_timestamp) ` +
-		`VALUES ($1, $2, $3, $4, $5)`
+		`(from_addr, to_addr, burn_asset, burn_e8, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, $4, $5, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fake.BlockTimestamp)
 	MustExec(t, insertq,
@@ -352,8 +391,8 @@ func InsertSwitchEvent(t *testing.T, fake FakeSwitch) {
 
 func InsertRewardsEvent(t *testing.T, bondE8 int64, fakeTimestamp string) {
 	const insertq = `INSERT INTO rewards_events ` +
-		`(bond_e8, block_timestamp) ` +
-		`VALUES ($1, $2)`
+		`(bond_e8, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fakeTimestamp)
 	MustExec(t, insertq, bondE8, timestamp)
@@ -361,8 +400,8 @@ func InsertRewardsEvent(t *testing.T, bondE8 int64, fakeTimestamp string) {
 
 func InsertRewardsEventEntry(t *testing.T, bondE8 int64, pool, fakeTimestamp string) {
 	const insertq = `INSERT INTO rewards_event_entries ` +
-		`(rune_e8, block_timestamp, pool) ` +
-		`VALUES ($1, $2, $3)`
+		`(rune_e8, block_timestamp, pool, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(fakeTimestamp)
 	MustExec(t, insertq, bondE8, timestamp, pool)
@@ -379,8 +418,8 @@ func InsertBlockLog(t *testing.T, height int64, fakeTimestamp string) {
 
 func InsertPoolEvents(t *testing.T, pool, status string) {
 	const insertq = `INSERT INTO  pool_events` +
-		`(asset, status, block_timestamp) ` +
-		`VALUES ($1, $2, 1)`
+		`(asset, status, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, 1, ` + rawPositionValues + `)`
 
 	MustExec(t, insertq, pool, status)
 }
@@ -394,6 +433,13 @@ func InsertBlockPoolDepth(t *testing.T, pool string, assetE8, runeE8 int64, bloc
 	MustExec(t, insertq, pool, assetE8, runeE8, timestamp)
 }
 
+func InsertBlockPoolUnits(t *testing.T, pool string, units int64, blockTimestamp string) {
+	const insertq = `INSERT INTO block_pool_units (pool, units, block_timestamp) VALUES ($1, $2, $3)`
+
+	timestamp := nanoWithDefault(blockTimestamp)
+	MustExec(t, insertq, pool, units, timestamp)
+}
+
 type FakeNodeStatus struct {
 	NodeAddr string
 	Former   string
@@ -402,8 +448,8 @@ type FakeNodeStatus struct {
 
 func InsertUpdateNodeAccountStatusEvent(t *testing.T, fake FakeNodeStatus, blockTimestamp string) {
 	const insertq = `INSERT INTO update_node_account_status_events ` +
-		`(node_addr, former, current, block_timestamp) ` +
-		`VALUES ($1, $2, $3, $4)`
+		`(node_addr, former, current, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, $4, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(blockTimestamp)
 	MustExec(t, insertq, fake.NodeAddr, fake.Former, fake.Current, timestamp)
@@ -421,8 +467,8 @@ func getEnvVariable(key, def string) string {
 
 func InsertActiveVaultEvent(t *testing.T, address string, blockTimestamp string) {
 	const insertq = `INSERT INTO active_vault_events ` +
-		`(add_asgard_addr, block_timestamp) ` +
-		`VALUES ($1, $2)`
+		`(add_asgard_addr, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(blockTimestamp)
 	MustExec(t, insertq, address, timestamp)
@@ -446,8 +492,8 @@ func SetThornodeConstants(t *testing.T, constants *FakeThornodeConstants, timest
 
 func insertMimirEvent(t *testing.T, key string, value int64, blockTimestamp string) {
 	const insertq = `INSERT INTO set_mimir_events ` +
-		`(key, value, block_timestamp) ` +
-		`VALUES ($1, $2, $3)`
+		`(key, value, block_timestamp, ` + rawPosition + `) ` +
+		`VALUES ($1, $2, $3, ` + rawPositionValues + `)`
 
 	timestamp := nanoWithDefault(blockTimestamp)
 	MustExec(t, insertq, key, strconv.FormatInt(value, 10), timestamp)
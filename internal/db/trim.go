@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DeleteBlocksFrom deletes all rows including and after the given block from every table.
+// Tables are trimmed by their block_height column, or else by block_timestamp or by height.
+// Aggregates are not touched, drop them with DropAggregates.
+func DeleteBlocksFrom(ctx context.Context, height int64, timestamp Nano) error {
+	log.Info().Msgf("Deleting rows including and after height %d , timestamp %d", height, timestamp)
+	tables, err := tableColumns(ctx)
+	if err != nil {
+		return err
+	}
+	for table, columns := range tables {
+		if columns["block_height"] {
+			log.Info().Msgf("%s deleting by block_height", table)
+			err = deleteAfter(table, "block_height", height)
+		} else if columns["block_timestamp"] {
+			log.Info().Msgf("%s deleting by block_timestamp", table)
+			err = deleteAfter(table, "block_timestamp", timestamp.ToI())
+		} else if columns["height"] {
+			log.Info().Msgf("%s deleting by height", table)
+			err = deleteAfter(table, "height", height)
+		} else if table == "constants" {
+			log.Info().Msgf("Skipping table %s", table)
+		} else {
+			log.Warn().Msgf("table %s has no good column", table)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteAfter(table string, columnName string, value int64) error {
+	q := fmt.Sprintf("DELETE FROM %s WHERE $1 <= %s", table, columnName)
+	_, err := Exec(q, value)
+	if err != nil {
+		return fmt.Errorf("delete from %s failed: %w", table, err)
+	}
+	return nil
+}
+
+type tableMap map[string]map[string]bool
+
+func tableColumns(ctx context.Context) (tableMap, error) {
+	q := `
+	SELECT
+		table_name,
+		column_name
+	FROM information_schema.columns
+	WHERE table_schema='midgard'
+	`
+	rows, err := PrimaryQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := tableMap{}
+	for rows.Next() {
+		var table, column string
+		err := rows.Scan(&table, &column)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := ret[table]; !ok {
+			ret[table] = map[string]bool{}
+		}
+		ret[table][column] = true
+	}
+	return ret, rows.Err()
+}
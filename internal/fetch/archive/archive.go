@@ -0,0 +1,317 @@
+// Package archive keeps fetched blocks in local files, such that the database can be rebuilt
+// without Tendermint.
+//
+// Blocks are stored in segments of consecutive heights. Each segment is a gzip file with one
+// JSON record per line, named after its first height. The segment being written has a ".tmp"
+// suffix until it is complete.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"gitlab.com/thorchain/midgard/internal/fetch/chain"
+)
+
+var logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}).With().Timestamp().Str("module", "archive").Logger()
+
+// SegmentSize is the maximum number of blocks per file.
+const SegmentSize = 1000
+
+const (
+	segmentSuffix = ".gz"
+	tmpSuffix     = ".tmp"
+)
+
+// Record is the file representation of a chain.Block.
+type record struct {
+	Height     int64                         `json:"height"`
+	Time       time.Time                     `json:"time"`
+	Hash       []byte                        `json:"hash"`
+	ParentHash []byte                        `json:"parent_hash"`
+	Results    *coretypes.ResultBlockResults `json:"results"`
+}
+
+func segmentName(firstHeight int64) string {
+	return fmt.Sprintf("%012d%s", firstHeight, segmentSuffix)
+}
+
+// Segments returns the first heights of the complete segments in dir, in ascending order.
+func segments(dir string) ([]int64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var ret []int64
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), segmentSuffix) {
+			continue
+		}
+		height, err := strconv.ParseInt(strings.TrimSuffix(f.Name(), segmentSuffix), 10, 64)
+		if err != nil {
+			logger.Warn().Msgf("Ignoring unknown file %q in block archive", f.Name())
+			continue
+		}
+		ret = append(ret, height)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret, nil
+}
+
+// ReadSegment calls f for each block in the file, in order. A truncated file ends without
+// error on the last complete block.
+func readSegment(path string, f func(chain.Block) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err == io.EOF {
+		// empty file
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("block archive %q: %w", path, err)
+	}
+	r := bufio.NewReader(gz)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				// partial line is lost
+				return nil
+			}
+			return fmt.Errorf("block archive %q: %w", path, err)
+		}
+		var rec record
+		if err := tmjson.Unmarshal(line, &rec); err != nil {
+			return fmt.Errorf("block archive %q: %w", path, err)
+		}
+		err = f(chain.Block{
+			Height:     rec.Height,
+			Time:       rec.Time,
+			Hash:       rec.Hash,
+			ParentHash: rec.ParentHash,
+			Results:    rec.Results,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Writer appends blocks to the archive. It is not thread safe.
+type Writer struct {
+	dir string
+
+	// Segment in progress, if any.
+	file        *os.File
+	gz          *gzip.Writer
+	firstHeight int64
+	count       int64
+
+	// NextHeight is the height expected for the next Write.
+	nextHeight int64
+}
+
+// NewWriter opens the archive in dir, creating the directory when needed. A segment left
+// incomplete by a previous run is recovered up to its last complete block.
+func NewWriter(dir string) (*Writer, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{dir: dir}
+
+	tmps, err := filepath.Glob(filepath.Join(dir, "*"+segmentSuffix+tmpSuffix))
+	if err != nil {
+		return nil, err
+	}
+	for _, tmp := range tmps {
+		err = w.recover(tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	firstHeights, err := segments(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(firstHeights) != 0 {
+		path := filepath.Join(dir, segmentName(firstHeights[len(firstHeights)-1]))
+		err = readSegment(path, func(block chain.Block) error {
+			w.nextHeight = block.Height + 1
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// Recover rewrites the complete blocks of a leftover segment as a final one.
+func (w *Writer) recover(tmp string) error {
+	var blocks []chain.Block
+	err := readSegment(tmp, func(block chain.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info().Msgf("Recovering %d blocks from incomplete block archive segment %q", len(blocks), tmp)
+	if err := os.Remove(tmp); err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		if err := w.Write(block); err != nil {
+			return err
+		}
+	}
+	return w.finishSegment()
+}
+
+// NextHeight returns the height which continues the archive, or 0 when empty.
+func (w *Writer) NextHeight() int64 {
+	return w.nextHeight
+}
+
+// Write adds the block. Heights already archived are ignored. When the block doesn't follow
+// the previous one, it starts a new segment.
+func (w *Writer) Write(block chain.Block) error {
+	if block.Height < w.nextHeight {
+		return nil
+	}
+	if w.file != nil && (block.Height != w.nextHeight || w.count >= SegmentSize) {
+		if err := w.finishSegment(); err != nil {
+			return err
+		}
+	}
+	if w.file == nil {
+		if err := w.startSegment(block.Height); err != nil {
+			return err
+		}
+	}
+
+	line, err := tmjson.Marshal(record{
+		Height:     block.Height,
+		Time:       block.Time,
+		Hash:       block.Hash,
+		ParentHash: block.ParentHash,
+		Results:    block.Results,
+	})
+	if err != nil {
+		return fmt.Errorf("block archive height %d: %w", block.Height, err)
+	}
+	if _, err := w.gz.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// Keeps the file readable up to here when the process dies.
+	if err := w.gz.Flush(); err != nil {
+		return err
+	}
+	w.count++
+	w.nextHeight = block.Height + 1
+	return nil
+}
+
+func (w *Writer) startSegment(firstHeight int64) error {
+	file, err := os.Create(filepath.Join(w.dir, segmentName(firstHeight)+tmpSuffix))
+	if err != nil {
+		return err
+	}
+	w.file, w.gz = file, gzip.NewWriter(file)
+	w.firstHeight, w.count = firstHeight, 0
+	return nil
+}
+
+func (w *Writer) finishSegment() error {
+	if w.file == nil {
+		return nil
+	}
+	file, gz := w.file, w.gz
+	w.file, w.gz = nil, nil
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	name := filepath.Join(w.dir, segmentName(w.firstHeight))
+	return os.Rename(name+tmpSuffix, name)
+}
+
+// Close completes the segment in progress.
+func (w *Writer) Close() error {
+	return w.finishSegment()
+}
+
+// Source reads blocks from the archive, as a replacement for the Tendermint client.
+type Source struct {
+	dir string
+}
+
+// NewSource reads the archive in dir.
+func NewSource(dir string) *Source {
+	return &Source{dir: dir}
+}
+
+// CatchUp sends all consecutive blocks starting at nextHeight to out. Like chain.Client
+// CatchUp, the error return is never nil, and chain.ErrNoData marks the end of the archive.
+func (s *Source) CatchUp(ctx context.Context, out chan<- chain.Block, nextHeight int64) (
+	height int64, err error) {
+	firstHeights, err := segments(s.dir)
+	if err != nil {
+		return nextHeight, err
+	}
+	i := sort.Search(len(firstHeights), func(i int) bool { return firstHeights[i] > nextHeight }) - 1
+	if i < 0 {
+		return nextHeight, chain.ErrNoData
+	}
+
+	errEnd := errors.New("end of consecutive blocks")
+	for ; i < len(firstHeights) && firstHeights[i] <= nextHeight; i++ {
+		path := filepath.Join(s.dir, segmentName(firstHeights[i]))
+		err = readSegment(path, func(block chain.Block) error {
+			if block.Height < nextHeight {
+				return nil
+			}
+			if block.Height != nextHeight {
+				return errEnd
+			}
+			select {
+			case <-ctx.Done():
+				return chain.ErrQuit
+			case out <- block:
+				nextHeight++
+				return nil
+			}
+		})
+		if err == errEnd {
+			break
+		}
+		if err != nil {
+			return nextHeight, err
+		}
+	}
+	return nextHeight, chain.ErrNoData
+}
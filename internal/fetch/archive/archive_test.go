@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"gitlab.com/thorchain/midgard/internal/fetch/chain"
+)
+
+func testBlock(height int64) chain.Block {
+	return chain.Block{
+		Height:     height,
+		Time:       time.Unix(height, 0).UTC(),
+		Hash:       []byte(fmt.Sprintf("hash%d", height)),
+		ParentHash: []byte(fmt.Sprintf("hash%d", height-1)),
+		Results: &coretypes.ResultBlockResults{
+			Height: height,
+			EndBlockEvents: []abci.Event{{Type: "pool", Attributes: []abci.EventAttribute{
+				{Key: []byte("pool"), Value: []byte("BNB.BNB")},
+			}}},
+		},
+	}
+}
+
+func writeHeights(t *testing.T, dir string, from, to int64) {
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+	for h := from; h <= to; h++ {
+		require.NoError(t, w.Write(testBlock(h)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func readHeights(t *testing.T, dir string, nextHeight int64) (blocks []chain.Block, height int64) {
+	out := make(chan chain.Block, 10000)
+	height, err := NewSource(dir).CatchUp(context.Background(), out, nextHeight)
+	require.Equal(t, chain.ErrNoData, err)
+	close(out)
+	for block := range out {
+		blocks = append(blocks, block)
+	}
+	return
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "archive")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	writeHeights(t, dir, 1, 2*SegmentSize+10)
+
+	first, err := segments(dir)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, SegmentSize + 1, 2*SegmentSize + 1}, first)
+
+	blocks, height := readHeights(t, dir, 5)
+	require.Equal(t, int64(2*SegmentSize+11), height)
+	require.Len(t, blocks, 2*SegmentSize+6)
+	require.Equal(t, testBlock(5), blocks[0])
+	require.Equal(t, testBlock(2*SegmentSize+10), blocks[len(blocks)-1])
+}
+
+func TestContinueAndGap(t *testing.T) {
+	dir := tempDir(t)
+	writeHeights(t, dir, 1, 10)
+	// already archived heights are skipped
+	writeHeights(t, dir, 5, 20)
+	// the gap ends the replay
+	writeHeights(t, dir, 30, 40)
+
+	blocks, height := readHeights(t, dir, 1)
+	require.Len(t, blocks, 20)
+	require.Equal(t, int64(21), height)
+
+	blocks, height = readHeights(t, dir, 35)
+	require.Len(t, blocks, 6)
+	require.Equal(t, int64(41), height)
+
+	blocks, height = readHeights(t, dir, 25)
+	require.Empty(t, blocks)
+	require.Equal(t, int64(25), height)
+}
+
+func TestRecoverIncomplete(t *testing.T) {
+	dir := tempDir(t)
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+	for h := int64(1); h <= 10; h++ {
+		require.NoError(t, w.Write(testBlock(h)))
+	}
+	// simulate a crash, with the last block cut short
+	tmp := filepath.Join(dir, segmentName(1)+tmpSuffix)
+	info, err := os.Stat(tmp)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(tmp, info.Size()-20))
+
+	w, err = NewWriter(dir)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), w.NextHeight())
+	require.NoError(t, w.Close())
+
+	blocks, _ := readHeights(t, dir, 1)
+	require.Len(t, blocks, 9)
+}
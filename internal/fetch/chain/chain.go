@@ -2,12 +2,14 @@
 package chain
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/pascaldekloe/metrics"
@@ -15,6 +17,7 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
 	"gitlab.com/thorchain/midgard/config"
 	"gitlab.com/thorchain/midgard/internal/db"
 	"gitlab.com/thorchain/midgard/internal/util/miderr"
@@ -35,16 +38,31 @@ func init() {
 	metrics.MustHelp("midgard_chain_height", "The latest Tendermint sequence identifier reported by the node.")
 }
 
+// ActiveNode is 1 for the Tendermint node [identifier] currently in use and 0 for the others.
+var ActiveNode = metrics.Must1LabelInteger("midgard_chain_node_active", "node")
+
+func init() {
+	metrics.MustHelp("midgard_chain_node_active", "Whether the Tendermint node is the one blocks are fetched from.")
+}
+
 // Block is a chain record.
 type Block struct {
-	Height  int64     // sequence identifier
-	Time    time.Time // establishment timestamp
-	Hash    []byte    // content identifier
-	Results *coretypes.ResultBlockResults
+	Height     int64     // sequence identifier
+	Time       time.Time // establishment timestamp
+	Hash       []byte    // content identifier
+	ParentHash []byte    // content identifier of the previous block
+	Results    *coretypes.ResultBlockResults
 }
 
-// Client provides Tendermint access.
-type Client struct {
+// Endpoint is a single Tendermint RPC node.
+type endpoint struct {
+	// URL is the configured address, used in logs.
+	url string
+
+	// Node is the Tendermint node identifier as reported by Status.
+	// The URL is used until the first successful health check.
+	node string
+
 	// StatusClient has a Tendermint connection.
 	statusClient rpcclient.StatusClient
 
@@ -54,46 +72,242 @@ type Client struct {
 	// SignClient has a Tendermint connection.
 	signClient rpcclient.SignClient
 
-	// SignBatchClient has a Tendermint connection in batch mode.
-	signBatchClient rpcclient.SignClient
-
-	// SignBatchClientTrigger executes enqueued requests (on SignClient).
+	// NewBatch returns a Tendermint connection in batch mode and its trigger, which executes
+	// the enqueued requests. Batches are not thread safe, each fetch creates its own.
 	// See github.com/tendermint/tendermint/rpchttp/client/http BatchHTTP.
-	signBatchClientTrigger func(ctx context.Context) ([]interface{}, error)
+	newBatch func() (rpcclient.SignClient, func(ctx context.Context) ([]interface{}, error))
+
+	// Subscribe starts a NewBlock subscription over the Tendermint websocket. The events
+	// channel is never closed. Unsubscribe closes the connection.
+	subscribe func(ctx context.Context) (events <-chan coretypes.ResultEvent, unsubscribe func(), err error)
+}
+
+// Client provides Tendermint access.
+// Blocks are fetched from one endpoint at a time. When it fails or falls behind, the client
+// switches over to the most advanced healthy one.
+type Client struct {
+	// AnnouncedHeight is the latest height from a NewBlock event, accessed atomically.
+	// First in the struct for 64-bit alignment.
+	announcedHeight int64
+
+	endpoints []*endpoint
+
+	// Workers is the number of batches fetched in parallel.
+	workers int
+
+	// PrefetchDepth is the number of batches which may be requested ahead of the one
+	// waited for by the writer.
+	prefetchDepth int
+
+	// Last block submitted, used for verifying the parent hash of the next one also after
+	// a failover. Used only from CatchUp.
+	lastHeight int64
+	lastHash   []byte
+
+	// Subscribed is 1 while NewBlock events arrive, accessed atomically.
+	subscribed int32
+
+	// SubscribeTimeout is how long without NewBlock event drops the subscription, and
+	// subscribeRetry how long to wait before subscribing again.
+	subscribeTimeout time.Duration
+	subscribeRetry   time.Duration
+
+	// Active is the endpoint in use, guarded by mu.
+	mu     sync.Mutex
+	active *endpoint
 }
 
 func (client *Client) DebugFetchResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
-	return client.signClient.BlockResults(ctx, &height)
+	return client.activeEndpoint().signClient.BlockResults(ctx, &height)
 }
 
 // NewClient configures a new instance. Timeout applies to all requests on endpoint.
 func NewClient(c *config.Config) (*Client, error) {
 	var timeout time.Duration = c.ThorChain.ReadTimeout.WithDefault(8 * time.Second)
 
-	endpoint, err := url.Parse(c.ThorChain.TendermintURL)
+	if len(c.ThorChain.TendermintURLs) == 0 {
+		return nil, errors.New("no Tendermint RPC URL configured")
+	}
+	ret := &Client{
+		workers:          c.ThorChain.FetchWorkers,
+		prefetchDepth:    c.ThorChain.FetchPrefetchDepth,
+		subscribeTimeout: c.ThorChain.NewBlockTimeout.WithDefault(30 * time.Second),
+		subscribeRetry:   c.ThorChain.LastChainBackoff.WithDefault(7 * time.Second),
+	}
+	if ret.workers < 1 {
+		ret.workers = 4
+	}
+	if ret.prefetchDepth == 0 {
+		ret.prefetchDepth = 2 * ret.workers
+	} else if ret.prefetchDepth < ret.workers {
+		// Workers would be idle.
+		ret.prefetchDepth = ret.workers
+	}
+	logger.Info().Msgf("Fetching blocks with %d workers, prefetching %d batches",
+		ret.workers, ret.prefetchDepth)
+
+	for _, tendermintURL := range c.ThorChain.TendermintURLs {
+		e, err := newEndpoint(tendermintURL, timeout)
+		if err != nil {
+			return nil, err
+		}
+		ret.endpoints = append(ret.endpoints, e)
+	}
+	ret.active = ret.endpoints[0]
+	return ret, nil
+}
+
+func newEndpoint(tendermintURL string, timeout time.Duration) (*endpoint, error) {
+	u, err := url.Parse(tendermintURL)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Exit on malformed Tendermint RPC URL")
 	}
 	// need the path seperate from the URL for some reason
-	path := endpoint.Path
-	endpoint.Path = ""
-	remote := endpoint.String()
+	path := u.Path
+	u.Path = ""
+	remote := u.String()
 
 	// rpchttp.NewWithTimeout rounds to seconds for some reason
 	client, err := rpchttp.NewWithClient(remote, path, &http.Client{Timeout: timeout})
 	if err != nil {
-		return nil, fmt.Errorf("Tendermint RPC client instantiation: %w", err)
+		return nil, fmt.Errorf("Tendermint RPC client instantiation for %q: %w", tendermintURL, err)
 	}
-	batchClient := client.NewBatch()
-	return &Client{
-		statusClient:           client,
-		historyClient:          client,
-		signClient:             client,
-		signBatchClient:        batchClient,
-		signBatchClientTrigger: batchClient.Send,
+	return &endpoint{
+		url:           tendermintURL,
+		node:          tendermintURL,
+		statusClient:  client,
+		historyClient: client,
+		signClient:    client,
+		newBatch: func() (rpcclient.SignClient, func(ctx context.Context) ([]interface{}, error)) {
+			batchClient := client.NewBatch()
+			return batchClient, batchClient.Send
+		},
+		subscribe: func(ctx context.Context) (<-chan coretypes.ResultEvent, func(), error) {
+			// A stopped websocket can't be started again, each subscription gets its own.
+			eventsClient, err := rpchttp.New(remote, path)
+			if err != nil {
+				return nil, nil, err
+			}
+			err = eventsClient.Start()
+			if err != nil {
+				return nil, nil, err
+			}
+			unsubscribe := func() {
+				// Discarding errors
+				_ = eventsClient.Stop()
+			}
+			events, err := eventsClient.Subscribe(ctx, "midgard", types.EventQueryNewBlock.String())
+			if err != nil {
+				unsubscribe()
+				return nil, nil, err
+			}
+			return events, unsubscribe, nil
+		},
 	}, nil
 }
 
+func (c *Client) activeEndpoint() *endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active
+}
+
+func (c *Client) setActiveEndpoint(e *endpoint) {
+	c.mu.Lock()
+	previous := c.active
+	c.active = e
+	c.mu.Unlock()
+
+	if previous != e {
+		logger.Warn().Msgf("Switching Tendermint RPC from %q to %q", previous.url, e.url)
+	}
+	for _, other := range c.endpoints {
+		if other == e {
+			ActiveNode(other.node).Set(1)
+		} else {
+			ActiveNode(other.node).Set(0)
+		}
+	}
+}
+
+// SelectEndpoint health checks all endpoints and activates the one with the highest block.
+// Endpoints which don't have nextHeight anymore (pruned) are skipped. On equal heights the
+// currently active endpoint is preferred. Failed endpoints are excluded, it's used for failover.
+func (c *Client) selectEndpoint(ctx context.Context, nextHeight int64, failed map[*endpoint]bool) (
+	*endpoint, *coretypes.ResultStatus, error) {
+	type result struct {
+		status *coretypes.ResultStatus
+		err    error
+	}
+	results := make([]result, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		if failed[e] {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			results[i].status, results[i].err = e.statusClient.Status(ctx)
+		}(i, e)
+	}
+	wg.Wait()
+
+	active := c.activeEndpoint()
+	var lastErr error
+	var best *endpoint
+	var bestStatus *coretypes.ResultStatus
+	for i, e := range c.endpoints {
+		if failed[e] {
+			continue
+		}
+		status, err := results[i].status, results[i].err
+		if err != nil {
+			logger.Info().Err(err).Msgf("Tendermint RPC %q unhealthy", e.url)
+			lastErr = err
+			continue
+		}
+
+		e.node = string(status.NodeInfo.DefaultNodeID)
+		NodeHeight(e.node).Set(float64(status.SyncInfo.LatestBlockHeight), time.Now())
+
+		if nextHeight < status.SyncInfo.EarliestBlockHeight {
+			logger.Info().Msgf("Tendermint RPC %q has no block %d, earliest is %d",
+				e.url, nextHeight, status.SyncInfo.EarliestBlockHeight)
+			continue
+		}
+		if best == nil ||
+			bestStatus.SyncInfo.LatestBlockHeight < status.SyncInfo.LatestBlockHeight ||
+			(bestStatus.SyncInfo.LatestBlockHeight == status.SyncInfo.LatestBlockHeight && e == active) {
+			best, bestStatus = e, status
+		}
+	}
+	if best == nil {
+		if lastErr != nil {
+			return nil, nil, fmt.Errorf("none of the %d Tendermint RPC endpoints is available: %w",
+				len(c.endpoints), lastErr)
+		}
+		return nil, nil, fmt.Errorf("none of the %d Tendermint RPC endpoints has block %d",
+			len(c.endpoints), nextHeight)
+	}
+	c.setActiveEndpoint(best)
+	return best, bestStatus, nil
+}
+
+// BlockHashes returns the content identifiers of the blocks [from, to] on the active endpoint.
+// The range is limited to 20 blocks by Tendermint.
+func (c *Client) BlockHashes(ctx context.Context, from, to int64) (map[int64][]byte, error) {
+	info, err := c.activeEndpoint().historyClient.BlockchainInfo(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("Tendermint RPC BlockchainInfo %d–%d: %w", from, to, err)
+	}
+	ret := make(map[int64][]byte, len(info.BlockMetas))
+	for _, meta := range info.BlockMetas {
+		ret[meta.Header.Height] = []byte(meta.BlockID.Hash)
+	}
+	return ret, nil
+}
+
 // ErrNoData is an up-to-date status.
 var ErrNoData = errors.New("no more data on blockchain")
 
@@ -141,23 +355,22 @@ func CreateWebsocketChannel() {
 
 // CatchUp reads the latest block height from Status then it fetches all blocks from offset to
 // that height.
+// When the active endpoint fails, the next best endpoint takes over from the same height.
 // The error return is never nil. See ErrQuit and ErrNoData for normal exit.
 func (c *Client) CatchUp(ctx context.Context, out chan<- Block, nextHeight int64) (
 	height int64, err error) {
 	originalNextHeight := nextHeight
-	status, err := c.statusClient.Status(ctx)
+	// Endpoints which failed during this call are not retried until the next one.
+	failed := map[*endpoint]bool{}
+	e, status, err := c.selectEndpoint(ctx, nextHeight, failed)
 	if err != nil {
 		return nextHeight, fmt.Errorf("Tendermint RPC status unavailable: %w", err)
 	}
 	// Prints out only the first time, because we have shorter timeout later.
 	reportDetailed(status, nextHeight, 10)
 
-	statusTime := time.Now()
-	node := string(status.NodeInfo.DefaultNodeID)
-	cursorHeight := CursorHeight(node)
-	cursorHeight.Set(status.SyncInfo.EarliestBlockHeight)
-	nodeHeight := NodeHeight(node)
-	nodeHeight.Set(float64(status.SyncInfo.LatestBlockHeight), statusTime)
+	cursorHeight := CursorHeight(e.node)
+	cursorHeight.Set(nextHeight)
 
 	for {
 		if ctx.Err() != nil {
@@ -173,66 +386,164 @@ func (c *Client) CatchUp(ctx context.Context, out chan<- Block, nextHeight int64
 			return nextHeight, ErrNoData
 		}
 
-		// The maximum batch size is 20, because the limit of the historyClient.
-		// https://github.com/tendermint/tendermint/issues/5339
-		// If this turns out to be slow we can increase the batch size by calling
-		// batch history client.
-		const maxBatchSize = 20
-		batchSize := int64(maxBatchSize)
-		remaining := status.SyncInfo.LatestBlockHeight - nextHeight + 1
-		if remaining < batchSize {
-			batchSize = remaining
-		}
-		batch := make([]Block, batchSize)
-
-		n, err := c.fetchBlocks(ctx, batch, nextHeight)
+		nextHeight, err = c.fetchRange(ctx, e, out, nextHeight, status.SyncInfo.LatestBlockHeight, cursorHeight)
 		if err != nil {
-			return nextHeight, err
+			if ctx.Err() != nil {
+				return nextHeight, nil
+			}
+			failed[e] = true
+			if len(failed) == len(c.endpoints) {
+				return nextHeight, err
+			}
+			logger.Warn().Err(err).Msgf("Tendermint RPC %q failed at height %d", e.url, nextHeight)
+			// Only complete batches are submitted in order, the next endpoint continues
+			// from nextHeight.
+			e, status, err = c.selectEndpoint(ctx, nextHeight, failed)
+			if err != nil {
+				return nextHeight, err
+			}
+			cursorHeight = CursorHeight(e.node)
+			cursorHeight.Set(nextHeight)
 		}
+	}
+}
 
-		if n == 0 {
-			select { // must check quit, even on no data
-			default:
-				return nextHeight, miderr.InternalErrF(
-					"Faild to fetch blocks, was expecting %d blocks", batchSize)
+// The maximum batch size is 20, because the limit of the historyClient.
+// https://github.com/tendermint/tendermint/issues/5339
+// Throughput is increased with parallel batches instead, see fetchRange.
+const maxBatchSize = 20
+
+// BatchTask is a range of blocks fetched by one of the workers.
+type batchTask struct {
+	offset int64
+	batch  []Block
+	n      int
+	err    error
+	done   chan struct{}
+}
+
+// FetchRange fetches blocks [from, to] from the endpoint with parallel workers, and submits
+// them to out strictly in height order.
+// The return is the next height to fetch, which is valid on errors too. When the node
+// returns less blocks than requested it returns early without error.
+func (c *Client) fetchRange(ctx context.Context, e *endpoint, out chan<- Block, from, to int64,
+	cursorHeight *metrics.Integer) (nextHeight int64, err error) {
+	nextHeight = from
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// Tasks are in height order, the capacity limits how far workers get ahead.
+	tasks := make(chan *batchTask, c.prefetchDepth)
+	work := make(chan *batchTask)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(work)
+		defer close(tasks)
+		for offset := from; offset <= to; offset += maxBatchSize {
+			size := to - offset + 1
+			if maxBatchSize < size {
+				size = maxBatchSize
+			}
+			t := &batchTask{offset: offset, batch: make([]Block, size), done: make(chan struct{})}
+			select {
+			case tasks <- t:
 			case <-ctx.Done():
-				return nextHeight, nil
+				return
+			}
+			select {
+			case work <- t:
+			case <-ctx.Done():
+				return
 			}
 		}
+	}()
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range work {
+				t.n, t.err = e.fetchBlocks(ctx, t.batch, t.offset)
+				close(t.done)
+			}
+		}()
+	}
+
+	// reorder stage
+	for t := range tasks {
+		waitDone := fetchTimerWait.One()
+		select {
+		case <-t.done:
+		case <-ctx.Done():
+			return nextHeight, ctx.Err()
+		}
+		waitDone()
+
+		if t.err != nil {
+			return nextHeight, t.err
+		}
+		if t.n == 0 {
+			return nextHeight, miderr.InternalErrF(
+				"Faild to fetch blocks, was expecting %d blocks", len(t.batch))
+		}
 
 		// submit batch[:n]
-		for i := 0; i < n; i++ {
+		for i := 0; i < t.n; i++ {
+			if t.batch[i].Height != nextHeight {
+				return nextHeight, fmt.Errorf(
+					"Tendermint RPC returned height %d, was expecting %d", t.batch[i].Height, nextHeight)
+			}
+			if c.lastHash != nil && c.lastHeight == nextHeight-1 && !bytes.Equal(t.batch[i].ParentHash, c.lastHash) {
+				return nextHeight, fmt.Errorf(
+					"Tendermint RPC block %d has parent hash %X, but block %d was %X",
+					nextHeight, t.batch[i].ParentHash, c.lastHeight, c.lastHash)
+			}
 			select {
 			case <-ctx.Done():
-				return nextHeight, nil
-			case out <- batch[i]:
-				nextHeight = batch[i].Height + 1
+				return nextHeight, ctx.Err()
+			case out <- t.batch[i]:
+				c.lastHeight, c.lastHash = t.batch[i].Height, t.batch[i].Hash
+				nextHeight = t.batch[i].Height + 1
 				cursorHeight.Set(nextHeight)
 
 				// report every so often in batch mode too.
-				if 1 < batchSize && nextHeight%1000 == 1 {
-					reportProgress(nextHeight, status.SyncInfo.LatestBlockHeight)
+				if 1 < len(t.batch) && nextHeight%1000 == 1 {
+					reportProgress(nextHeight, to)
 				}
 			}
 		}
 
 		// Notify websockets if we already passed batch mode.
-		if batchSize < maxBatchSize-1 && WebsocketNotify != nil {
+		if len(t.batch) < maxBatchSize-1 && WebsocketNotify != nil {
 			select {
 			case *WebsocketNotify <- struct{}{}:
 			default:
 			}
 		}
+
+		if t.n < len(t.batch) {
+			// Following tasks would leave a gap.
+			return nextHeight, nil
+		}
 	}
+	return nextHeight, nil
 }
 
 var (
 	fetchTimerBatch  = timer.NewTimer("block_fetch_batch")
 	fetchTimerSingle = timer.NewTimer("block_fetch_single")
+
+	// Time the writer side waits for the next batch, high values mean more workers could help.
+	fetchTimerWait = timer.NewTimer("block_fetch_reorder_wait")
 )
 
 // FetchBlocks resolves n blocks into batch, starting at the offset (height).
-func (c *Client) fetchBlocks(ctx context.Context, batch []Block, offset int64) (n int, err error) {
+func (c *endpoint) fetchBlocks(ctx context.Context, batch []Block, offset int64) (n int, err error) {
 	if 1 == len(batch) {
 		defer fetchTimerSingle.One()()
 	} else {
@@ -262,16 +573,27 @@ func (c *Client) fetchBlocks(ctx context.Context, batch []Block, offset int64) (
 		return 0, fmt.Errorf("Tendermint RPC BlockchainInfo %d–%d got %d–%d", offset, last, low, high)
 	}
 
+	var batchClient rpcclient.SignClient
+	var batchTrigger func(ctx context.Context) ([]interface{}, error)
+	if 1 < len(batch) {
+		batchClient, batchTrigger = c.newBatch()
+	}
+
 	// setup blocks for batch request
 	for i := len(info.BlockMetas) - 1; i >= 0; i-- {
 		batch[n].Height = info.BlockMetas[i].Header.Height
 		batch[n].Time = info.BlockMetas[i].Header.Time
 		batch[n].Hash = []byte(info.BlockMetas[i].BlockID.Hash)
+		batch[n].ParentHash = []byte(info.BlockMetas[i].Header.LastBlockID.Hash)
+		if 0 < n && !bytes.Equal(batch[n].ParentHash, batch[n-1].Hash) {
+			return 0, fmt.Errorf("Tendermint RPC BlockchainInfo %d–%d got block %d with parent hash %X instead of %X",
+				offset, last, batch[n].Height, batch[n].ParentHash, batch[n-1].Hash)
+		}
 
 		// We get unmarshalling error from the batch client if we have one call only.
 		// For this reason we call signClient when there is one call only.
 		if 1 < len(batch) {
-			batch[n].Results, err = c.signBatchClient.BlockResults(ctx, &info.BlockMetas[i].Header.Height)
+			batch[n].Results, err = batchClient.BlockResults(ctx, &info.BlockMetas[i].Header.Height)
 		} else {
 			batch[n].Results, err = c.signClient.BlockResults(ctx, &info.BlockMetas[i].Header.Height)
 		}
@@ -283,7 +605,7 @@ func (c *Client) fetchBlocks(ctx context.Context, batch []Block, offset int64) (
 	}
 
 	if 1 < len(batch) {
-		if _, err := c.signBatchClientTrigger(ctx); err != nil {
+		if _, err := batchTrigger(ctx); err != nil {
 			return 0, fmt.Errorf("Tendermint RPC batch BlockResults %d–%d: %w", offset, last, err)
 		}
 	}
@@ -0,0 +1,233 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/p2p"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// FakeNode serves blocks [1, height], and fails on every block starting at failFrom.
+// Blocks starting at forkFrom have different hashes.
+type fakeNode struct {
+	rpcclient.SignClient // only BlockResults is implemented
+	id                   string
+	forkFrom             int64
+	height               int64
+	failFrom             int64
+	statusErr            error
+	jitter               bool // random response times
+	events               chan coretypes.ResultEvent
+}
+
+func (n *fakeNode) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
+	if n.statusErr != nil {
+		return nil, n.statusErr
+	}
+	ret := &coretypes.ResultStatus{}
+	ret.NodeInfo.DefaultNodeID = p2p.ID(n.id)
+	ret.SyncInfo.EarliestBlockHeight = 1
+	ret.SyncInfo.LatestBlockHeight = n.height
+	return ret, nil
+}
+
+func (n *fakeNode) Genesis(context.Context) (*coretypes.ResultGenesis, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (n *fakeNode) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (
+	*coretypes.ResultBlockchainInfo, error) {
+	if n.failFrom != 0 && n.failFrom <= maxHeight {
+		return nil, errors.New("node stalled")
+	}
+	if n.jitter {
+		time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	}
+	ret := &coretypes.ResultBlockchainInfo{LastHeight: n.height}
+	for h := maxHeight; minHeight <= h; h-- {
+		meta := &types.BlockMeta{}
+		meta.Header.Height = h
+		meta.Header.Time = time.Unix(h, 0)
+		meta.BlockID.Hash = n.hash(h)
+		meta.Header.LastBlockID.Hash = n.hash(h - 1)
+		ret.BlockMetas = append(ret.BlockMetas, meta)
+	}
+	return ret, nil
+}
+
+func (n *fakeNode) hash(height int64) []byte {
+	if n.forkFrom != 0 && n.forkFrom <= height {
+		return []byte(fmt.Sprintf("fork%d", height))
+	}
+	return []byte(fmt.Sprintf("main%d", height))
+}
+
+func (n *fakeNode) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{Height: *height}, nil
+}
+
+func fakeClient(nodes ...*fakeNode) *Client {
+	ret := &Client{}
+	for _, n := range nodes {
+		n := n
+		ret.endpoints = append(ret.endpoints, &endpoint{
+			url:           "http://" + n.id,
+			node:          n.id,
+			statusClient:  n,
+			historyClient: n,
+			signClient:    n,
+			newBatch: func() (rpcclient.SignClient, func(context.Context) ([]interface{}, error)) {
+				return n, func(context.Context) ([]interface{}, error) { return nil, nil }
+			},
+			subscribe: func(context.Context) (<-chan coretypes.ResultEvent, func(), error) {
+				if n.events == nil {
+					return nil, nil, errors.New("websocket unavailable")
+				}
+				return n.events, func() {}, nil
+			},
+		})
+	}
+	ret.active = ret.endpoints[0]
+	ret.workers, ret.prefetchDepth = 1, 1
+	ret.subscribeTimeout, ret.subscribeRetry = 50*time.Millisecond, 10*time.Millisecond
+	return ret
+}
+
+func catchUpHeights(t *testing.T, c *Client, nextHeight int64) (heights []int64, err error) {
+	out := make(chan Block, 10000)
+	_, err = c.CatchUp(context.Background(), out, nextHeight)
+	close(out)
+	for block := range out {
+		heights = append(heights, block.Height)
+	}
+	return
+}
+
+func TestSelectsMostAdvanced(t *testing.T) {
+	c := fakeClient(
+		&fakeNode{id: "behind", height: 10},
+		&fakeNode{id: "ahead", height: 30},
+		&fakeNode{id: "down", height: 50, statusErr: errors.New("connection refused")})
+
+	heights, err := catchUpHeights(t, c, 25)
+	require.Equal(t, ErrNoData, err)
+	require.Equal(t, []int64{25, 26, 27, 28, 29, 30}, heights)
+	require.Equal(t, "ahead", c.activeEndpoint().node)
+}
+
+func TestFailoverMidCatchUp(t *testing.T) {
+	c := fakeClient(
+		&fakeNode{id: "stalling", height: 50, failFrom: 33},
+		&fakeNode{id: "healthy", height: 50})
+
+	heights, err := catchUpHeights(t, c, 1)
+	require.Equal(t, ErrNoData, err)
+	require.Len(t, heights, 50)
+	for i, h := range heights {
+		require.Equal(t, int64(i+1), h, "heights must be continuous")
+	}
+	require.Equal(t, "healthy", c.activeEndpoint().node)
+}
+
+func TestParallelFetchInOrder(t *testing.T) {
+	c := fakeClient(&fakeNode{id: "a", height: 1234, jitter: true})
+	c.workers, c.prefetchDepth = 5, 8
+
+	heights, err := catchUpHeights(t, c, 7)
+	require.Equal(t, ErrNoData, err)
+	require.Len(t, heights, 1234-6)
+	for i, h := range heights {
+		require.Equal(t, int64(i+7), h, "heights must be in order")
+	}
+}
+
+func TestParallelFailover(t *testing.T) {
+	c := fakeClient(
+		&fakeNode{id: "stalling", height: 500, failFrom: 333, jitter: true},
+		&fakeNode{id: "healthy", height: 500, jitter: true})
+	c.workers, c.prefetchDepth = 4, 4
+
+	heights, err := catchUpHeights(t, c, 1)
+	require.Equal(t, ErrNoData, err)
+	require.Len(t, heights, 500)
+	for i, h := range heights {
+		require.Equal(t, int64(i+1), h, "heights must be continuous")
+	}
+}
+
+func TestAllEndpointsDown(t *testing.T) {
+	c := fakeClient(
+		&fakeNode{id: "a", height: 50, failFrom: 5},
+		&fakeNode{id: "b", height: 50, failFrom: 5})
+
+	heights, err := catchUpHeights(t, c, 1)
+	require.Error(t, err)
+	require.NotEqual(t, ErrNoData, err)
+	require.Empty(t, heights)
+}
+
+func TestForkedEndpointRejected(t *testing.T) {
+	c := fakeClient(
+		&fakeNode{id: "stalling", height: 100, failFrom: 53},
+		&fakeNode{id: "forked", height: 100, forkFrom: 30})
+
+	heights, err := catchUpHeights(t, c, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parent hash")
+	require.Equal(t, int64(40), heights[len(heights)-1])
+}
+
+func newBlockEvent(height int64) coretypes.ResultEvent {
+	block := &types.Block{}
+	block.Height = height
+	return coretypes.ResultEvent{Data: types.EventDataNewBlock{Block: block}}
+}
+
+func TestSubscribeNotifies(t *testing.T) {
+	n := &fakeNode{id: "a", height: 10, events: make(chan coretypes.ResultEvent)}
+	c := fakeClient(n)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notify := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		c.Subscribe(ctx, notify)
+		close(done)
+	}()
+
+	n.events <- newBlockEvent(11)
+	<-notify
+	require.True(t, c.Subscribed())
+	require.Equal(t, int64(11), c.AnnouncedHeight())
+
+	// no more events drops the subscription
+	require.Eventually(t, func() bool { return !c.Subscribed() }, time.Second, time.Millisecond)
+
+	// renewed on the next event
+	n.events <- newBlockEvent(12)
+	<-notify
+	require.True(t, c.Subscribed())
+	require.Equal(t, int64(12), c.AnnouncedHeight())
+
+	cancel()
+	<-done
+	require.False(t, c.Subscribed())
+}
+
+func TestSubscribeUnavailable(t *testing.T) {
+	c := fakeClient(&fakeNode{id: "a", height: 10})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	c.Subscribe(ctx, make(chan struct{}, 1))
+	require.False(t, c.Subscribed())
+}
@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pascaldekloe/metrics"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Subscribed is 1 while NewBlock events arrive from the Tendermint websocket and 0 otherwise.
+var Subscribed = metrics.MustInteger("midgard_chain_subscribed",
+	"Whether new blocks are pushed by the Tendermint node, instead of polled for.")
+
+// Subscribe follows the NewBlock events of the active endpoint, and signals notify for each
+// one. The subscription is considered dropped when no event arrives within the timeout, in
+// which case it is renewed, on the then active endpoint, after the retry interval.
+// Returns when ctx is done.
+func (c *Client) Subscribe(ctx context.Context, notify chan<- struct{}) {
+	defer c.setSubscribed(false)
+	for {
+		e := c.activeEndpoint()
+		events, unsubscribe, err := e.subscribe(ctx)
+		if err != nil {
+			logger.Info().Err(err).Msgf("Tendermint RPC NewBlock subscription on %q failed, polling", e.url)
+		} else {
+			c.followEvents(ctx, e, events, notify)
+			unsubscribe()
+		}
+
+		select {
+		case <-time.After(c.subscribeRetry):
+			// Noop
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// FollowEvents returns when ctx is done, or when no event arrives within the timeout.
+func (c *Client) followEvents(ctx context.Context, e *endpoint, events <-chan coretypes.ResultEvent, notify chan<- struct{}) {
+	timeout := time.NewTimer(c.subscribeTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case event := <-events:
+			if data, ok := event.Data.(types.EventDataNewBlock); ok && data.Block != nil {
+				atomic.StoreInt64(&c.announcedHeight, data.Block.Height)
+			}
+			if !c.Subscribed() {
+				logger.Info().Msgf("Tendermint RPC NewBlock subscription on %q active", e.url)
+				c.setSubscribed(true)
+			}
+			select {
+			case notify <- struct{}{}:
+			default:
+				// A fetch is pending already.
+			}
+
+			if !timeout.Stop() {
+				<-timeout.C
+			}
+			timeout.Reset(c.subscribeTimeout)
+
+		case <-timeout.C:
+			if c.Subscribed() {
+				logger.Warn().Msgf("No Tendermint RPC NewBlock event from %q in %s, polling",
+					e.url, c.subscribeTimeout)
+				c.setSubscribed(false)
+			}
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Subscribed returns whether NewBlock events are being received.
+func (c *Client) Subscribed() bool {
+	return atomic.LoadInt32(&c.subscribed) != 0
+}
+
+// AnnouncedHeight returns the height of the latest NewBlock event received, or 0 when none.
+func (c *Client) AnnouncedHeight() int64 {
+	return atomic.LoadInt64(&c.announcedHeight)
+}
+
+func (c *Client) setSubscribed(subscribed bool) {
+	if subscribed {
+		atomic.StoreInt32(&c.subscribed, 1)
+		Subscribed.Set(1)
+	} else {
+		atomic.StoreInt32(&c.subscribed, 0)
+		Subscribed.Set(0)
+	}
+}
@@ -0,0 +1,269 @@
+// Package fakenode serves blocks with the Tendermint RPC protocol, as a stand-in for a
+// THORNode in end-to-end tests.
+//
+// The status, blockchain and block_results methods are available with JSON-RPC (including
+// batches) and with URI requests, like on Tendermint. On the websocket, clients can subscribe
+// to NewBlock events.
+package fakenode
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+
+	"gitlab.com/thorchain/midgard/internal/fetch/chain"
+)
+
+// Maximum number of blocks per blockchain request, as on Tendermint.
+const maxBlockchainRange = 20
+
+// Node is an in-memory chain.
+type Node struct {
+	chainID string
+
+	mu sync.Mutex
+	// Blocks[i] has height i+1.
+	blocks        []chain.Block
+	subscriptions []subscription
+}
+
+type subscription struct {
+	conn  rpctypes.WSRPCConnection
+	req   rpctypes.RPCRequest
+	query string
+}
+
+// New returns an empty chain. The chain ID is reported as the network in status.
+func New(chainID string) *Node {
+	return &Node{chainID: chainID}
+}
+
+// Add appends blocks to the chain, and notifies the subscribers. A zero height gets the next
+// one, and missing hashes are generated.
+func (n *Node) Add(blocks ...chain.Block) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, block := range blocks {
+		next := int64(len(n.blocks) + 1)
+		if block.Height == 0 {
+			block.Height = next
+		}
+		if block.Height != next {
+			return fmt.Errorf("block height %d added after %d", block.Height, next-1)
+		}
+		if block.Results == nil {
+			block.Results = &coretypes.ResultBlockResults{}
+		}
+		block.Results.Height = block.Height
+		if block.Hash == nil {
+			block.Hash = n.hash(block.Height)
+		}
+		if block.ParentHash == nil && 1 < next {
+			block.ParentHash = n.blocks[next-2].Hash
+		}
+		n.blocks = append(n.blocks, block)
+		n.notify(block)
+	}
+	return nil
+}
+
+// Hash is deterministic per chain ID and height.
+func (n *Node) hash(height int64) []byte {
+	h := sha256.New()
+	h.Write([]byte(n.chainID))
+	// Discarding errors
+	_ = binary.Write(h, binary.BigEndian, height)
+	return h.Sum(nil)
+}
+
+// Height returns the latest height, 0 when empty.
+func (n *Node) Height() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return int64(len(n.blocks))
+}
+
+// Handler serves the RPC on "/", and the websocket on "/websocket".
+func (n *Node) Handler() http.Handler {
+	funcs := map[string]*rpcserver.RPCFunc{
+		"status":        rpcserver.NewRPCFunc(n.status, ""),
+		"blockchain":    rpcserver.NewRPCFunc(n.blockchain, "minHeight,maxHeight"),
+		"block_results": rpcserver.NewRPCFunc(n.blockResults, "height"),
+		"subscribe":     rpcserver.NewWSRPCFunc(n.subscribe, "query"),
+	}
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, funcs, tmlog.NewNopLogger())
+	mux.HandleFunc("/websocket", rpcserver.NewWebsocketManager(funcs).WebsocketHandler)
+	return mux
+}
+
+func (n *Node) status(ctx *rpctypes.Context) (*coretypes.ResultStatus, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ret := &coretypes.ResultStatus{}
+	ret.NodeInfo.DefaultNodeID = p2p.ID(fmt.Sprintf("fakenode-%x", n.hash(0)[:8]))
+	ret.NodeInfo.Network = n.chainID
+	if len(n.blocks) != 0 {
+		last := n.blocks[len(n.blocks)-1]
+		ret.SyncInfo.EarliestBlockHeight = 1
+		ret.SyncInfo.EarliestBlockHash = n.blocks[0].Hash
+		ret.SyncInfo.EarliestBlockTime = n.blocks[0].Time
+		ret.SyncInfo.LatestBlockHeight = last.Height
+		ret.SyncInfo.LatestBlockHash = last.Hash
+		ret.SyncInfo.LatestBlockTime = last.Time
+	}
+	return ret, nil
+}
+
+func (n *Node) blockchain(ctx *rpctypes.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	height := int64(len(n.blocks))
+
+	// same range logic as Tendermint
+	if minHeight < 0 || maxHeight < 0 {
+		return nil, errors.New("heights must be non-negative")
+	}
+	if minHeight == 0 {
+		minHeight = 1
+	}
+	if maxHeight == 0 || height < maxHeight {
+		maxHeight = height
+	}
+	if maxHeight-maxBlockchainRange+1 > minHeight {
+		minHeight = maxHeight - maxBlockchainRange + 1
+	}
+	if minHeight > maxHeight {
+		return nil, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+
+	ret := &coretypes.ResultBlockchainInfo{LastHeight: height}
+	for h := maxHeight; minHeight <= h; h-- {
+		block := n.blocks[h-1]
+		meta := &types.BlockMeta{}
+		meta.BlockID.Hash = block.Hash
+		meta.Header.ChainID = n.chainID
+		meta.Header.Height = block.Height
+		meta.Header.Time = block.Time
+		meta.Header.LastBlockID.Hash = block.ParentHash
+		meta.NumTxs = len(block.Results.TxsResults)
+		ret.BlockMetas = append(ret.BlockMetas, meta)
+	}
+	return ret, nil
+}
+
+func (n *Node) blockResults(ctx *rpctypes.Context, heightPtr *int64) (*coretypes.ResultBlockResults, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	height := int64(len(n.blocks))
+	if heightPtr != nil {
+		if *heightPtr <= 0 {
+			return nil, fmt.Errorf("height must be greater than 0, but got %d", *heightPtr)
+		}
+		if height < *heightPtr {
+			return nil, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d",
+				*heightPtr, height)
+		}
+		height = *heightPtr
+	}
+	if height == 0 {
+		return nil, errors.New("no blocks yet")
+	}
+	return n.blocks[height-1].Results, nil
+}
+
+func (n *Node) subscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultSubscribe, error) {
+	if query != types.EventQueryNewBlock.String() {
+		return nil, fmt.Errorf("only %q subscriptions are supported", types.EventQueryNewBlock)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.subscriptions = append(n.subscriptions, subscription{
+		conn:  ctx.WSConn,
+		req:   *ctx.JSONReq,
+		query: query,
+	})
+	return &coretypes.ResultSubscribe{}, nil
+}
+
+// Notify sends a NewBlock event to the subscribers. Must be called with n.mu held.
+func (n *Node) notify(block chain.Block) {
+	event := types.EventDataNewBlock{Block: &types.Block{}}
+	event.Block.ChainID = n.chainID
+	event.Block.Height = block.Height
+	event.Block.Time = block.Time
+	event.Block.LastBlockID.Hash = block.ParentHash
+
+	subscriptions := n.subscriptions[:0]
+	for _, s := range n.subscriptions {
+		if s.conn.Context().Err() != nil {
+			// connection closed
+			continue
+		}
+		subscriptions = append(subscriptions, s)
+		resp := rpctypes.NewRPCSuccessResponse(s.req.ID, &coretypes.ResultEvent{
+			Query: s.query,
+			Data:  event,
+		})
+		// Slow clients miss events, like on Tendermint.
+		s.conn.TryWriteRPCResponse(resp)
+	}
+	n.subscriptions = subscriptions
+}
+
+// Fixture is the file representation of a block. Results has the format of the block_results
+// method result.
+type fixture struct {
+	Time    time.Time                     `json:"time"`
+	Results *coretypes.ResultBlockResults `json:"results"`
+}
+
+// ReadFixtures reads a JSON array of blocks, with their time and results, from a file.
+// Heights are numbered from 1 in order, regardless of the results content.
+func ReadFixtures(path string) ([]chain.Block, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []fixture
+	if err := tmjson.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("fixtures %q: %w", path, err)
+	}
+	ret := make([]chain.Block, len(fixtures))
+	for i, f := range fixtures {
+		ret[i] = chain.Block{Time: f.Time, Results: f.Results}
+	}
+	return ret, nil
+}
+
+// ReleaseEvery adds the blocks one at a time with interval, for simulating a live chain.
+// Returns when all blocks are added, or when ctx is done.
+func (n *Node) ReleaseEvery(ctx context.Context, interval time.Duration, blocks []chain.Block) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for _, block := range blocks {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := n.Add(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package fakenode_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"gitlab.com/thorchain/midgard/config"
+	"gitlab.com/thorchain/midgard/internal/fetch/chain"
+	"gitlab.com/thorchain/midgard/internal/fetch/fakenode"
+)
+
+func poolEvent(height int) abci.Event {
+	return abci.Event{Type: "pool", Attributes: []abci.EventAttribute{
+		{Key: []byte("pool"), Value: []byte(fmt.Sprintf("BNB.TOKEN-%d", height))},
+		{Key: []byte("pool_status"), Value: []byte("Enabled")},
+	}}
+}
+
+func startNode(t *testing.T, blockCount int) (*fakenode.Node, *chain.Client) {
+	node := fakenode.New("fakechain")
+	for i := 1; i <= blockCount; i++ {
+		require.NoError(t, node.Add(chain.Block{
+			Time: time.Unix(int64(i)*5, 0).UTC(),
+			Results: &coretypes.ResultBlockResults{
+				EndBlockEvents: []abci.Event{poolEvent(i)},
+			},
+		}))
+	}
+	srv := httptest.NewServer(node.Handler())
+	t.Cleanup(srv.Close)
+
+	var c config.Config
+	c.ThorChain.TendermintURLs = []string{srv.URL + "/websocket"}
+	c.ThorChain.NewBlockTimeout = config.Duration(time.Second)
+	c.ThorChain.LastChainBackoff = config.Duration(10 * time.Millisecond)
+	client, err := chain.NewClient(&c)
+	require.NoError(t, err)
+	return node, client
+}
+
+func TestCatchUp(t *testing.T) {
+	_, client := startNode(t, 123)
+
+	out := make(chan chain.Block, 200)
+	height, err := client.CatchUp(context.Background(), out, 1)
+	require.Equal(t, chain.ErrNoData, err)
+	require.Equal(t, int64(124), height)
+	close(out)
+
+	var previous chain.Block
+	for block := range out {
+		require.Equal(t, previous.Height+1, block.Height)
+		require.Equal(t, time.Unix(block.Height*5, 0).UTC(), block.Time.UTC())
+		require.Equal(t, []abci.Event{poolEvent(int(block.Height))}, block.Results.EndBlockEvents)
+		if previous.Height != 0 {
+			require.Equal(t, previous.Hash, block.ParentHash)
+		}
+		previous = block
+	}
+	require.Equal(t, int64(123), previous.Height)
+}
+
+func TestNewBlockSubscription(t *testing.T) {
+	node, client := startNode(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notify := make(chan struct{}, 1)
+	go client.Subscribe(ctx, notify)
+
+	// the subscription is made asynchronously, keep adding until it is noticed
+	require.Eventually(t, func() bool {
+		require.NoError(t, node.Add(chain.Block{Time: time.Now()}))
+		select {
+		case <-notify:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 50*time.Millisecond)
+	require.True(t, client.Subscribed())
+	require.Eventually(t, func() bool {
+		return client.AnnouncedHeight() == node.Height()
+	}, 5*time.Second, 10*time.Millisecond)
+}
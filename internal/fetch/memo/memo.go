@@ -0,0 +1,169 @@
+// Package memo parses the transaction memos of THORChain.
+//
+//	memo   :≡ action | action ':' params
+//	params :≡ param | param ':' params
+//
+// The action is case insensitive and has aliases, e.g. "SWAP", "s" and "=" are all swaps.
+package memo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Action is the canonical name of a memo action.
+type Action string
+
+// Memo actions
+const (
+	Swap            Action = "swap"
+	Add             Action = "add"
+	Withdraw        Action = "withdraw"
+	Donate          Action = "donate"
+	Bond            Action = "bond"
+	Unbond          Action = "unbond"
+	Leave           Action = "leave"
+	Reserve         Action = "reserve"
+	Refund          Action = "refund"
+	Outbound        Action = "out"
+	Migrate         Action = "migrate"
+	Ragnarok        Action = "ragnarok"
+	Switch          Action = "switch"
+	NoOp            Action = "noop"
+	YggdrasilFund   Action = "yggdrasil+"
+	YggdrasilReturn Action = "yggdrasil-"
+)
+
+// Actions has the action per alias, in lower case.
+var actions = map[string]Action{
+	"swap":       Swap,
+	"s":          Swap,
+	"=":          Swap,
+	"add":        Add,
+	"a":          Add,
+	"+":          Add,
+	"withdraw":   Withdraw,
+	"wd":         Withdraw,
+	"-":          Withdraw,
+	"donate":     Donate,
+	"d":          Donate,
+	"bond":       Bond,
+	"unbond":     Unbond,
+	"leave":      Leave,
+	"reserve":    Reserve,
+	"refund":     Refund,
+	"out":        Outbound,
+	"outbound":   Outbound,
+	"migrate":    Migrate,
+	"ragnarok":   Ragnarok,
+	"switch":     Switch,
+	"noop":       NoOp,
+	"yggdrasil+": YggdrasilFund,
+	"yggdrasil-": YggdrasilReturn,
+}
+
+// Memo is a parsed memo. Fields which are not in the memo have the zero value.
+type Memo struct {
+	Action Action
+
+	// Pool of adds, withdraws and donates.
+	Pool string
+	// Swap target, or the asset of a single sided withdraw.
+	Asset string
+	// Swap destination, the paired address of adds, or the address of bond, unbond, leave
+	// and switch.
+	Address string
+	// Minimum swap output, in E8.
+	Limit int64
+	// Withdraw share, in basis points.
+	BasisPoints int64
+
+	// Affiliate of swaps and adds, with the fee in basis points.
+	AffiliateAddress     string
+	AffiliateBasisPoints int64
+}
+
+var errEmpty = errors.New("empty memo")
+
+// Parse decodes the memo. Unknown actions and malformed numbers are errors. Surplus
+// parameters are ignored.
+func Parse(memo string) (Memo, error) {
+	var m Memo
+	params := strings.Split(strings.TrimSpace(memo), ":")
+	if params[0] == "" {
+		return m, errEmpty
+	}
+	action, ok := actions[strings.ToLower(params[0])]
+	if !ok {
+		return m, fmt.Errorf("unknown memo action %q", params[0])
+	}
+	m.Action = action
+	params = params[1:]
+
+	var err error
+	switch action {
+	case Swap:
+		// SWAP:ASSET:DESTADDR:LIMIT:AFFILIATE:FEE
+		m.Asset = param(params, 0)
+		m.Address = param(params, 1)
+		if m.Limit, err = intParam(params, 2, "limit"); err != nil {
+			return m, err
+		}
+		m.AffiliateAddress = param(params, 3)
+		if m.AffiliateBasisPoints, err = intParam(params, 4, "affiliate fee"); err != nil {
+			return m, err
+		}
+	case Add:
+		// ADD:POOL:PAIREDADDR:AFFILIATE:FEE
+		m.Pool = param(params, 0)
+		m.Address = param(params, 1)
+		m.AffiliateAddress = param(params, 2)
+		if m.AffiliateBasisPoints, err = intParam(params, 3, "affiliate fee"); err != nil {
+			return m, err
+		}
+	case Withdraw:
+		// WITHDRAW:POOL:BASISPOINTS:ASSET
+		m.Pool = param(params, 0)
+		if m.BasisPoints, err = intParam(params, 1, "basis points"); err != nil {
+			return m, err
+		}
+		m.Asset = param(params, 2)
+	case Donate:
+		// DONATE:POOL
+		m.Pool = param(params, 0)
+	case Bond, Unbond, Leave, Switch:
+		// BOND:NODEADDR, UNBOND:NODEADDR:AMOUNT, LEAVE:NODEADDR, SWITCH:ADDR
+		m.Address = param(params, 0)
+	}
+	return m, nil
+}
+
+// Target is the asset the memo is after: Asset when set, Pool otherwise.
+func (m *Memo) Target() string {
+	if m.Asset != "" {
+		return m.Asset
+	}
+	return m.Pool
+}
+
+func param(params []string, i int) string {
+	if i < len(params) {
+		return params[i]
+	}
+	return ""
+}
+
+// IntParam returns zero for absent and empty parameters.
+func intParam(params []string, i int, name string) (int64, error) {
+	s := param(params, i)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("malformed %s %q in memo", name, s)
+	}
+	return v, nil
+}
@@ -0,0 +1,65 @@
+package memo
+
+import "testing"
+
+var GoldenMemos = []struct {
+	Memo string
+	Want Memo
+}{
+	{"SWAP:ETH.ETH:0xaddr:1000", Memo{Action: Swap, Asset: "ETH.ETH", Address: "0xaddr", Limit: 1000}},
+	{"=:BTC.BTC:bc1addr::thor1aff:15", Memo{Action: Swap, Asset: "BTC.BTC", Address: "bc1addr",
+		AffiliateAddress: "thor1aff", AffiliateBasisPoints: 15}},
+	{"s:BNB.BNB", Memo{Action: Swap, Asset: "BNB.BNB"}},
+	{" swap:THOR.RUNE:thor1dest ", Memo{Action: Swap, Asset: "THOR.RUNE", Address: "thor1dest"}},
+	{"+:POOL", Memo{Action: Add, Pool: "POOL"}},
+	{"ADD:BTC.BTC:thor1paired:thor1aff:20", Memo{Action: Add, Pool: "BTC.BTC", Address: "thor1paired",
+		AffiliateAddress: "thor1aff", AffiliateBasisPoints: 20}},
+	{"-:POOL:5000", Memo{Action: Withdraw, Pool: "POOL", BasisPoints: 5000}},
+	{"WITHDRAW:BNB.BNB:10000:THOR.RUNE", Memo{Action: Withdraw, Pool: "BNB.BNB", BasisPoints: 10000,
+		Asset: "THOR.RUNE"}},
+	{"wd:BNB.BNB", Memo{Action: Withdraw, Pool: "BNB.BNB"}},
+	{"DONATE:ETH.ETH", Memo{Action: Donate, Pool: "ETH.ETH"}},
+	{"BOND:thor1node", Memo{Action: Bond, Address: "thor1node"}},
+	{"SWITCH:thor1dest", Memo{Action: Switch, Address: "thor1dest"}},
+	{"OUTBOUND:04FFE1117647700F48F678DF53372D503F31C745D6DDE3599D9CB6381188620E", Memo{Action: Outbound}},
+	{"reserve", Memo{Action: Reserve}},
+}
+
+func TestParse(t *testing.T) {
+	for _, gold := range GoldenMemos {
+		got, err := Parse(gold.Memo)
+		if err != nil {
+			t.Errorf("%q got error: %s", gold.Memo, err)
+			continue
+		}
+		if got != gold.Want {
+			t.Errorf("%q got %+v, want %+v", gold.Memo, got, gold.Want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, memo := range []string{
+		"",
+		"  ",
+		"nosuchaction:BTC.BTC",
+		"SWAP:ETH.ETH:0xaddr:lots",
+		"=:BTC.BTC:bc1addr::thor1aff:-1",
+		"-:POOL:half",
+	} {
+		if m, err := Parse(memo); err == nil {
+			t.Errorf("%q got %+v, want error", memo, m)
+		}
+	}
+}
+
+func TestTarget(t *testing.T) {
+	m := Memo{Action: Withdraw, Pool: "BNB.BNB"}
+	if got := m.Target(); got != "BNB.BNB" {
+		t.Errorf("withdraw got target %q, want the pool", got)
+	}
+	m.Asset = "THOR.RUNE"
+	if got := m.Target(); got != "THOR.RUNE" {
+		t.Errorf("single sided withdraw got target %q, want the asset", got)
+	}
+}
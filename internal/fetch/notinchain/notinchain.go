@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -92,7 +93,20 @@ type NodeAccount struct {
 
 // Get all nodes from the thorchain api
 func NodeAccountsLookup() ([]*NodeAccount, error) {
-	resp, err := Client.Get(BaseURL + "/nodes")
+	return NodeAccountsLookupAt(0)
+}
+
+// The height query parameter of the thorchain api, 0 is the latest block.
+func heightQuery(height int64) string {
+	if height == 0 {
+		return ""
+	}
+	return "?height=" + strconv.FormatInt(height, 10)
+}
+
+// Get all nodes at a height from the thorchain api, this needs an archive node for old heights.
+func NodeAccountsLookupAt(height int64) ([]*NodeAccount, error) {
+	resp, err := Client.Get(BaseURL + "/nodes" + heightQuery(height))
 	if err != nil {
 		return nil, fmt.Errorf("node accounts unavailable from REST on %w", err)
 	}
@@ -128,7 +142,12 @@ type Network struct {
 
 // Get vault data from the thorchain api
 func NetworkLookup() (*Network, error) {
-	resp, err := Client.Get(BaseURL + "/network")
+	return NetworkLookupAt(0)
+}
+
+// Get vault data at a height from the thorchain api, this needs an archive node for old heights.
+func NetworkLookupAt(height int64) (*Network, error) {
+	resp, err := Client.Get(BaseURL + "/network" + heightQuery(height))
 	if err != nil {
 		return nil, fmt.Errorf("network data unavailable from REST on %w", err)
 	}
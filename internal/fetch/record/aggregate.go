@@ -5,12 +5,14 @@ type runningTotals struct {
 	// running totals
 	assetE8DepthPerPool map[string]*int64
 	runeE8DepthPerPool  map[string]*int64
+	unitsPerPool        map[string]*int64
 }
 
 func newRunningTotals() *runningTotals {
 	return &runningTotals{
 		assetE8DepthPerPool: make(map[string]*int64),
 		runeE8DepthPerPool:  make(map[string]*int64),
+		unitsPerPool:        make(map[string]*int64),
 	}
 }
 
@@ -32,6 +34,15 @@ func (t *runningTotals) AddPoolRuneE8Depth(pool []byte, runeE8 int64) {
 	}
 }
 
+// AddPoolUnits adjusts the quantity. Use a negative value to deduct.
+func (t *runningTotals) AddPoolUnits(pool []byte, units int64) {
+	if p, ok := t.unitsPerPool[string(pool)]; ok {
+		*p += units
+	} else {
+		t.unitsPerPool[string(pool)] = &units
+	}
+}
+
 func (t *runningTotals) SetAssetDepth(pool string, assetE8 int64) {
 	v := assetE8
 	t.assetE8DepthPerPool[pool] = &v
@@ -42,6 +53,11 @@ func (t *runningTotals) SetRuneDepth(pool string, runeE8 int64) {
 	t.runeE8DepthPerPool[pool] = &v
 }
 
+func (t *runningTotals) SetPoolUnits(pool string, units int64) {
+	v := units
+	t.unitsPerPool[pool] = &v
+}
+
 // AssetE8DepthPerPool returns a snapshot copy.
 func (t *runningTotals) AssetE8DepthPerPool() map[string]int64 {
 	m := make(map[string]int64, len(t.assetE8DepthPerPool))
@@ -59,3 +75,12 @@ func (t *runningTotals) RuneE8DepthPerPool() map[string]int64 {
 	}
 	return m
 }
+
+// UnitsPerPool returns a snapshot copy.
+func (t *runningTotals) UnitsPerPool() map[string]int64 {
+	m := make(map[string]int64, len(t.unitsPerPool))
+	for asset, p := range t.unitsPerPool {
+		m[asset] = *p
+	}
+	return m
+}
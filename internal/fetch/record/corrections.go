@@ -2,122 +2,267 @@
 //
 // In these cases we open a bug report so future events are correct, but the old events will
 // stay the same, and we apply these corrections to the existing events.
+//
+// The corrections are data, per chain ID and height. They come with the binary (see
+// correctionsdata.go), and CorrectionsFile can replace them without a rebuild.
 package record
 
 import (
-	"gitlab.com/thorchain/midgard/internal/db"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
 )
 
 const ChainIDMainnet202104 = "7D37DEF6E1BE23C912092069325C4A51E66B9EF7DDBDE004FF730CFABC0307B1"
 
-func CorrectWithdaws(withdraw *Unstake, meta *Metadata) {
-	f, ok := WithdrawCorrections[meta.BlockHeight]
-	if ok {
-		f(withdraw, meta)
-	}
+// CorrectionsVersion is the format version of the corrections data.
+const CorrectionsVersion = 1
+
+// CorrectionsFile, when set, is read by LoadCorrections instead of the embedded corrections.
+var CorrectionsFile string
+
+// Corrections is the root of the corrections data.
+type Corrections struct {
+	Version int               `json:"version"`
+	Chains  []ChainCorrection `json:"chains"`
 }
 
-func AddMissingEvents(d *Demux, meta *Metadata) {
-	f, ok := AdditionalEvents[meta.BlockHeight]
-	if ok {
-		f(d, meta)
-	}
-	// TODO(muninn): move fixes into separate files
-	switch db.ChainID() {
-	case ChainIDMainnet202104:
-		// Chaosnet started on 2021-04-10
-		switch meta.BlockHeight {
-		case 12824:
-			// Genesis node bonded rune and became listed as Active without any events.
-			d.reuse.UpdateNodeAccountStatus = UpdateNodeAccountStatus{
-				NodeAddr: []byte("thor1xfqaqhk5r6x9hdwlvmye0w9agv8ynljacmxulf"),
-				Former:   []byte("Ready"),
-				Current:  []byte("Active"),
-			}
-			Recorder.OnUpdateNodeAccountStatus(&d.reuse.UpdateNodeAccountStatus, meta)
-		case 63519:
-			// Fix was applied in https://gitlab.com/thorchain/thornode/-/merge_requests/1643
-			//
-			// TODO(acsaba): add PR/issue id as context for this, update reason.
-			// TODO(muninn): clarify with core team about
-			reason := []byte("Midgard fix for assymetric rune withdraw problem")
-			d.reuse.Unstake = Unstake{
-				FromAddr:   []byte("thor1tl9k7fjvye4hkvwdnl363g3f2xlpwwh7k7msaw"),
-				Chain:      []byte("BNB"),
-				Pool:       []byte("BNB.BNB"),
-				Asset:      []byte("THOR.RUNE"),
-				ToAddr:     reason,
-				Memo:       reason,
-				Tx:         reason,
-				EmitRuneE8: 1999997,
-				StakeUnits: 1029728,
-			}
-			Recorder.OnUnstake(&d.reuse.Unstake, meta)
-		}
-
-	case "8371BCEB807EEC52AC6A23E2FFC300D18FD3938374D3F4FC78EEB5FE33F78AF7":
-		// Testnet started on 2021-04-10
-		if meta.BlockHeight == 28795 {
-			// Withdraw id 57BD5B26B0D78CD4A0340F8ECA2356B23B029157E43DE99EF03114CC15577C8A
-			// failed, still pool balances were changed.
-			// Fix for future was submitted on Thornode:
-			// https://gitlab.com/thorchain/thornode/-/merge_requests/1634
-			d.reuse.PoolBalanceChange = PoolBalanceChange{
-				Asset:    []byte("LTC.LTC"),
-				RuneAmt:  1985607,
-				RuneAdd:  false,
-				AssetAmt: 93468,
-				AssetAdd: false,
-				Reason:   "Midgard fix: Reserve didn't have rune for gas",
+// ChainCorrection has the corrections of one chain.
+type ChainCorrection struct {
+	ChainID     string       `json:"chainID"`
+	Name        string       `json:"name"`
+	Corrections []Correction `json:"corrections"`
+}
+
+// Correction is a fix for a block. Add and Override may be combined.
+type Correction struct {
+	Height      int64  `json:"height"`
+	Description string `json:"description"`
+
+	// Event emitted after the events of the block.
+	Add *CorrectionEvent `json:"add,omitempty"`
+	// Fields set on the events of the block with a matching type.
+	Override *CorrectionEvent `json:"override,omitempty"`
+}
+
+// CorrectionEvent describes an event by its Go type name (e.g. "Unstake") and field names.
+// Byte slice fields take a JSON string.
+type CorrectionEvent struct {
+	Type string `json:"type"`
+	// Only for overrides: applies to events with these field values only.
+	Match  map[string]json.RawMessage `json:"match,omitempty"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// Corrections of the loaded chain, per height.
+var (
+	additionalEvents map[int64][]*CorrectionEvent
+	eventOverrides   map[int64][]*CorrectionEvent
+)
+
+// EventTypes has the event types per name, as found on the Listener methods.
+var eventTypes = func() map[string]reflect.Type {
+	m := make(map[string]reflect.Type)
+	listenerType := reflect.TypeOf((*Listener)(nil)).Elem()
+	for i := 0; i < listenerType.NumMethod(); i++ {
+		t := listenerType.Method(i).Type.In(0).Elem()
+		m[t.Name()] = t
+	}
+	return m
+}()
+
+// ReadCorrections gets CorrectionsFile, or the embedded corrections when not set.
+func ReadCorrections() (*Corrections, error) {
+	data := []byte(embeddedCorrections)
+	source := "embedded corrections"
+	if CorrectionsFile != "" {
+		var err error
+		data, err = ioutil.ReadFile(CorrectionsFile)
+		if err != nil {
+			return nil, err
+		}
+		source = CorrectionsFile
+	}
+
+	c := new(Corrections)
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	if c.Version != CorrectionsVersion {
+		return nil, fmt.Errorf("%s: version %d not supported, want %d", source, c.Version, CorrectionsVersion)
+	}
+	for _, chain := range c.Chains {
+		for _, correction := range chain.Corrections {
+			if err := correction.validate(); err != nil {
+				return nil, fmt.Errorf("%s: chain %q height %d: %w", source, chain.Name, correction.Height, err)
 			}
-			Recorder.OnPoolBalanceChange(&d.reuse.PoolBalanceChange, meta)
 		}
-	default:
 	}
+	return c, nil
 }
 
-type AddEventsFunc func(d *Demux, meta *Metadata)
-type AddEventsFuncMap map[int64]AddEventsFunc
-
-var AdditionalEvents AddEventsFuncMap
-
-type WithdrawCorrection func(withdraw *Unstake, meta *Metadata)
-type WithdrawCorrectionMap map[int64]WithdrawCorrection
+// For returns the corrections of the chain, ordered by height.
+func (c *Corrections) For(chainID string) []Correction {
+	var ret []Correction
+	for _, chain := range c.Chains {
+		if chain.ChainID == chainID {
+			ret = append(ret, chain.Corrections...)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Height < ret[j].Height })
+	return ret
+}
 
-var WithdrawCorrections WithdrawCorrectionMap
+func (c *Correction) validate() error {
+	if c.Add == nil && c.Override == nil {
+		return errors.New("neither add nor override")
+	}
+	if c.Add != nil {
+		if len(c.Add.Match) != 0 {
+			return errors.New("match on add")
+		}
+		if _, err := c.Add.newEvent(); err != nil {
+			return fmt.Errorf("add: %w", err)
+		}
+	}
+	if c.Override != nil {
+		if _, err := c.Override.newEvent(); err != nil {
+			return fmt.Errorf("override: %w", err)
+		}
+		// match values must decode too
+		if _, err := c.Override.matches(reflect.New(eventTypes[c.Override.Type]).Interface()); err != nil {
+			return fmt.Errorf("override: %w", err)
+		}
+	}
+	return nil
+}
 
+// LoadCorrections activates the corrections of the chain. Unreadable corrections are fatal.
 func LoadCorrections(chainID string) {
 	if chainID == "" {
 		return
 	}
-	AdditionalEvents = AddEventsFuncMap{}
-	WithdrawCorrections = WithdrawCorrectionMap{}
+	c, err := ReadCorrections()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read corrections")
+	}
 
-	LoadCorrectionsWithdrawImpLoss(chainID)
-	loadWithdrawForwardedAssetCorrections(chainID)
+	additionalEvents = make(map[int64][]*CorrectionEvent)
+	eventOverrides = make(map[int64][]*CorrectionEvent)
+	corrections := c.For(chainID)
+	for i := range corrections {
+		correction := &corrections[i]
+		if correction.Add != nil {
+			additionalEvents[correction.Height] = append(additionalEvents[correction.Height], correction.Add)
+		}
+		if correction.Override != nil {
+			eventOverrides[correction.Height] = append(eventOverrides[correction.Height], correction.Override)
+		}
+	}
+	log.Info().Msgf("Loaded %d corrections for chain %s", len(corrections), chainID)
 }
 
-// Note: we have copypasted Add functionsn because golang doesn't has templates yet.
-func (m AddEventsFuncMap) Add(height int64, f AddEventsFunc) {
-	fOrig, alreadyExists := m[height]
-	if alreadyExists {
-		m[height] = func(d *Demux, meta *Metadata) {
-			fOrig(d, meta)
-			f(d, meta)
+// NewEvent returns a pointer to a new event of the type, with the fields set.
+func (e *CorrectionEvent) newEvent() (interface{}, error) {
+	t, ok := eventTypes[e.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+	event := reflect.New(t).Interface()
+	if err := e.setFields(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// SetFields applies the field values to the event pointer.
+func (e *CorrectionEvent) setFields(event interface{}) error {
+	v := reflect.ValueOf(event).Elem()
+	for name, raw := range e.Fields {
+		field, err := fieldValue(v, name, raw)
+		if err != nil {
+			return err
 		}
-		return
+		v.FieldByName(name).Set(field)
 	}
-	m[height] = f
+	return nil
 }
 
-func (m WithdrawCorrectionMap) Add(height int64, f WithdrawCorrection) {
-	fOrig, alreadyExists := m[height]
-	if alreadyExists {
-		m[height] = func(withdraw *Unstake, meta *Metadata) {
-			fOrig(withdraw, meta)
-			f(withdraw, meta)
+// Matches returns whether all match fields equal those of the event pointer.
+func (e *CorrectionEvent) matches(event interface{}) (bool, error) {
+	v := reflect.ValueOf(event).Elem()
+	for name, raw := range e.Match {
+		want, err := fieldValue(v, name, raw)
+		if err != nil {
+			return false, err
+		}
+		if !reflect.DeepEqual(v.FieldByName(name).Interface(), want.Interface()) {
+			return false, nil
 		}
+	}
+	return true, nil
+}
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// FieldValue decodes raw as a value for the named field of v.
+func fieldValue(v reflect.Value, name string, raw json.RawMessage) (reflect.Value, error) {
+	field, ok := v.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%s has no field %q", v.Type().Name(), name)
+	}
+	if field.Type == bytesType {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, fmt.Errorf("field %s: %w", name, err)
+		}
+		return reflect.ValueOf([]byte(s)), nil
+	}
+	p := reflect.New(field.Type)
+	if err := json.Unmarshal(raw, p.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("field %s: %w", name, err)
+	}
+	return p.Elem(), nil
+}
+
+// ApplyOverrides sets the fields of the corrections for the height and type of event, which
+// is a pointer.
+func applyOverrides(event interface{}, meta *Metadata) {
+	overrides, ok := eventOverrides[meta.BlockHeight]
+	if !ok {
 		return
 	}
-	m[height] = f
+	typeName := reflect.TypeOf(event).Elem().Name()
+	for _, override := range overrides {
+		if override.Type != typeName {
+			continue
+		}
+		// errors excluded by validate
+		if ok, _ := override.matches(event); ok {
+			_ = override.setFields(event)
+		}
+	}
+}
+
+// AddMissingEvents emits the events which the corrections add to the block. They get the
+// positions after the events of the block.
+func AddMissingEvents(d *Demux, meta *Metadata) {
+	for _, add := range additionalEvents[meta.BlockHeight] {
+		event, err := add.newEvent()
+		if err != nil {
+			// excluded by validate
+			miderr.Printf("block height %d correction %s skipped: %s", meta.BlockHeight, add.Type, err)
+			continue
+		}
+		reflect.ValueOf(d.emit()).MethodByName("On" + add.Type).Call(
+			[]reflect.Value{reflect.ValueOf(event), reflect.ValueOf(meta)})
+		meta.EventIndex++
+	}
 }
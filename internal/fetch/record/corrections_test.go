@@ -0,0 +1,84 @@
+package record
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedCorrections(t *testing.T) {
+	c, err := ReadCorrections()
+	require.NoError(t, err)
+	mainnet := c.For(ChainIDMainnet202104)
+	require.Len(t, mainnet, 42)
+	for i := 1; i < len(mainnet); i++ {
+		require.LessOrEqual(t, mainnet[i-1].Height, mainnet[i].Height)
+	}
+	require.Empty(t, c.For("no such chain"))
+}
+
+type correctionListener struct {
+	NopListener
+	statuses []UpdateNodeAccountStatus
+}
+
+func (l *correctionListener) OnUpdateNodeAccountStatus(e *UpdateNodeAccountStatus, meta *Metadata) {
+	l.statuses = append(l.statuses, *e)
+}
+
+func TestCorrectionsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrections.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{
+	"version": 1,
+	"chains": [{
+		"chainID": "testchain",
+		"name": "test",
+		"corrections": [{
+			"height": 7,
+			"description": "node status",
+			"add": {"type": "UpdateNodeAccountStatus", "fields": {"NodeAddr": "thor1", "Current": "Active"}}
+		}, {
+			"height": 7,
+			"description": "units",
+			"override": {"type": "Unstake", "match": {"Tx": "AB"}, "fields": {"StakeUnits": 42, "Asymmetry": 0.5}}
+		}]
+	}]
+}`), 0o644))
+	defer func(old string) { CorrectionsFile = old }(CorrectionsFile)
+	CorrectionsFile = path
+	LoadCorrections("testchain")
+	defer LoadCorrections(ChainIDMainnet202104)
+
+	meta := Metadata{BlockHeight: 7}
+	matching := Unstake{Tx: []byte("AB"), StakeUnits: 99}
+	applyOverrides(&matching, &meta)
+	require.Equal(t, int64(42), matching.StakeUnits)
+	require.Equal(t, 0.5, matching.Asymmetry)
+
+	other := Unstake{Tx: []byte("CD"), StakeUnits: 99}
+	applyOverrides(&other, &meta)
+	require.Equal(t, int64(99), other.StakeUnits)
+
+	otherHeight := Unstake{Tx: []byte("AB"), StakeUnits: 99}
+	applyOverrides(&otherHeight, &Metadata{BlockHeight: 8})
+	require.Equal(t, int64(99), otherHeight.StakeUnits)
+
+	l := new(correctionListener)
+	d := Demux{listeners: listeners{l}}
+	AddMissingEvents(&d, &Metadata{BlockHeight: 7})
+	require.Equal(t, []UpdateNodeAccountStatus{{NodeAddr: []byte("thor1"), Current: []byte("Active")}}, l.statuses)
+}
+
+func TestCorrectionsValidate(t *testing.T) {
+	for _, c := range []Correction{
+		{Height: 1},
+		{Height: 1, Add: &CorrectionEvent{Type: "NoSuchEvent"}},
+		{Height: 1, Add: &CorrectionEvent{Type: "Stake", Fields: map[string]json.RawMessage{"NoSuchField": json.RawMessage("1")}}},
+		{Height: 1, Override: &CorrectionEvent{Type: "Stake", Match: map[string]json.RawMessage{"Pool": json.RawMessage("1")}}},
+	} {
+		require.Error(t, c.validate(), "height %d", c.Height)
+	}
+}
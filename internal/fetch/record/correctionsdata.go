@@ -0,0 +1,583 @@
+package record
+
+// Corrections which come with the binary. The format is described on Corrections. To try
+// changes without a rebuild, copy the JSON into a file and point corrections_file to it.
+//
+// Impermanent loss unit corrections are generated with:
+// $ go run ./cmd/onetime/fetchunits [config.json]
+
+const embeddedCorrections = `{
+	"version": 1,
+	"chains": [
+		{
+			"chainID": "7D37DEF6E1BE23C912092069325C4A51E66B9EF7DDBDE004FF730CFABC0307B1",
+			"name": "mainnet-202104",
+			"corrections": [
+				{
+					"height": 12824,
+					"description": "Genesis node bonded rune and became listed as Active without any events.",
+					"add": {
+						"type": "UpdateNodeAccountStatus",
+						"fields": {
+							"NodeAddr": "thor1xfqaqhk5r6x9hdwlvmye0w9agv8ynljacmxulf",
+							"Former": "Ready",
+							"Current": "Active"
+						}
+					}
+				},
+				{
+					"height": 29113,
+					"description": "The asset sent in with the withdraw initiation was not forwarded back to the user, fixed in https://gitlab.com/thorchain/thornode/-/merge_requests/1635",
+					"override": {
+						"type": "Unstake",
+						"fields": {
+							"AssetE8": 0
+						}
+					}
+				},
+				{
+					"height": 47832,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "4338F014E1FAC05C2248ECE0A36061D92CC76ADF13CCA773272AD70E00B56154"
+						},
+						"fields": {
+							"StakeUnits": 9066450465
+						}
+					}
+				},
+				{
+					"height": 63519,
+					"description": "Asymmetric rune withdraw without event, fixed in https://gitlab.com/thorchain/thornode/-/merge_requests/1643",
+					"add": {
+						"type": "Unstake",
+						"fields": {
+							"FromAddr": "thor1tl9k7fjvye4hkvwdnl363g3f2xlpwwh7k7msaw",
+							"Chain": "BNB",
+							"Pool": "BNB.BNB",
+							"Asset": "THOR.RUNE",
+							"ToAddr": "Midgard fix for assymetric rune withdraw problem",
+							"Memo": "Midgard fix for assymetric rune withdraw problem",
+							"Tx": "Midgard fix for assymetric rune withdraw problem",
+							"EmitRuneE8": 1999997,
+							"StakeUnits": 1029728
+						}
+					}
+				},
+				{
+					"height": 79082,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "A1B155BD4F57DDF91200733EE2552C9E0E828E632F0D91EF69BCAF3D74D8D512"
+						},
+						"fields": {
+							"StakeUnits": 169807962
+						}
+					}
+				},
+				{
+					"height": 81055,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "7613CEC05CA9B3A4BEF864F22E51EA29EB377EF4EC00885F91377F6D74D1DA4D"
+						},
+						"fields": {
+							"StakeUnits": 2267292958
+						}
+					}
+				},
+				{
+					"height": 81462,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "5E02AE1FE7A777BC6CBE8F4FC2DAFC9F8A6464BAAC58697202EAE1A2271D91D2"
+						},
+						"fields": {
+							"StakeUnits": 8002689544
+						}
+					}
+				},
+				{
+					"height": 84221,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "8885C9AC8A26002DA29090D6173D6A1C340AC6BD96837146BDA4ED059EF0760F"
+						},
+						"fields": {
+							"StakeUnits": 288123877
+						}
+					}
+				},
+				{
+					"height": 84876,
+					"description": "Pool units of the member went up on withdraw, https://gitlab.com/thorchain/thornode/-/issues/896",
+					"add": {
+						"type": "Stake",
+						"fields": {
+							"Pool": "BTC.BTC",
+							"RuneAddr": "thor1h7n7lakey4tah37226musffwjhhk558kaay6ur",
+							"StakeUnits": 2029187601
+						}
+					}
+				},
+				{
+					"height": 85406,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "E6907237BFFDFD5F733E5B422D4BC3106A8BCF933A7547843E458580C625D5D5"
+						},
+						"fields": {
+							"StakeUnits": 609672362
+						}
+					}
+				},
+				{
+					"height": 88797,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "F552E27BC9774E546CA4024B8274C758FC6433F3A38B0DB16137196F55E58C73"
+						},
+						"fields": {
+							"StakeUnits": 2208373135
+						}
+					}
+				},
+				{
+					"height": 89415,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "2E48177404B36CE893240A5B0CFF3FA501CE914BBA1F7D3FFEFC75D44110ADCF"
+						},
+						"fields": {
+							"StakeUnits": 767266632
+						}
+					}
+				},
+				{
+					"height": 90002,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "4D41DA864AE89E8B4CC315360F145E33501B2C1534A5757C1104606C967AB54F"
+						},
+						"fields": {
+							"StakeUnits": 19621520713
+						}
+					}
+				},
+				{
+					"height": 100196,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "C94BD47100E0C9983845735A3FA0C6C511713CB4486CBB3777F8DA386011A0C0"
+						},
+						"fields": {
+							"StakeUnits": 8280457915
+						}
+					}
+				},
+				{
+					"height": 105465,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "E86DCD9FDD898A3F7781D049EE0442DCC69ACBC2FBB110125A501AF7CF3003D7"
+						},
+						"fields": {
+							"StakeUnits": 911047010
+						}
+					}
+				},
+				{
+					"height": 109333,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "C1BD2175944D490D56755B37D1EB88385F9BF7A34EF609418A332526859C6EE2"
+						},
+						"fields": {
+							"StakeUnits": 406716426
+						}
+					}
+				},
+				{
+					"height": 110069,
+					"description": "The asset sent in with the withdraw initiation was not forwarded back to the user, fixed in https://gitlab.com/thorchain/thornode/-/merge_requests/1635",
+					"override": {
+						"type": "Unstake",
+						"fields": {
+							"AssetE8": 0
+						}
+					}
+				},
+				{
+					"height": 110069,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "8D5BBF31ABCB8297AB2804186D6AAA1B479E79B1CB0A0C1B2586F0F89225C28B"
+						},
+						"fields": {
+							"StakeUnits": 13600885317
+						}
+					}
+				},
+				{
+					"height": 112985,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "DAC7FCA92A9B42B82BFBE9C03C756A1AFBEF178CF8D2F6F2E044407A6696D581"
+						},
+						"fields": {
+							"StakeUnits": 117224625
+						}
+					}
+				},
+				{
+					"height": 128842,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "34B820F7158C3AB690C2DCF088356D1A70E6721551C2159C96729CE9FA97B698"
+						},
+						"fields": {
+							"StakeUnits": 93675000000
+						}
+					}
+				},
+				{
+					"height": 128845,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "0754C907993E389BA7947CB775D456BB829E12B3D7EEB676413E749BB847068B"
+						},
+						"fields": {
+							"StakeUnits": 146382616748
+						}
+					}
+				},
+				{
+					"height": 131366,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "8EEB3FBAA095F46E12207257C3CB0771BDB55C3EB2322F86FD75594ECC015AD1"
+						},
+						"fields": {
+							"StakeUnits": 45078869167
+						}
+					}
+				},
+				{
+					"height": 138590,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "7058BA9B3FF1173D620773458F84C5EA247EBB38C74C505E1FB8069CDB8A6E27"
+						},
+						"fields": {
+							"StakeUnits": 14950765467
+						}
+					}
+				},
+				{
+					"height": 147789,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "8CDA8459400D97CC436F1D19B6E42A4CEDDD21F2A231D1F9D4438B43A7750136"
+						},
+						"fields": {
+							"StakeUnits": 4873515514
+						}
+					}
+				},
+				{
+					"height": 147798,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "EAF6064BD7CB29389917BF4FF0D499D8E99890D9B561D8FF63F610092FADA4A3"
+						},
+						"fields": {
+							"StakeUnits": 814479987
+						}
+					}
+				},
+				{
+					"height": 151691,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "6A7A7C3A7A65F4704151DB1972EAFA6A237B03BA82D46721E761F3063753C42C"
+						},
+						"fields": {
+							"StakeUnits": 345151887
+						}
+					}
+				},
+				{
+					"height": 153980,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "85A19DA310282D35A6C51F4C34F921D27F2DF090535790F0C533FE61EA980CD7"
+						},
+						"fields": {
+							"StakeUnits": 1115323168
+						}
+					}
+				},
+				{
+					"height": 163137,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "0BA388B1BCF76C04B81D885ECB99E0E98A295778234FF9A88E9CA8ED69706DF4"
+						},
+						"fields": {
+							"StakeUnits": 3086810573
+						}
+					}
+				},
+				{
+					"height": 166532,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "156CCFBC66F775C7FDF9D3E18F071C6CEC2ADFAB4F7F435094AA516ECD1C698A"
+						},
+						"fields": {
+							"StakeUnits": 8288025767
+						}
+					}
+				},
+				{
+					"height": 170826,
+					"description": "Pool units of the member went up on withdraw, https://gitlab.com/thorchain/thornode/-/issues/896",
+					"add": {
+						"type": "Stake",
+						"fields": {
+							"Pool": "BNB.BNB",
+							"RuneAddr": "thor1t5t5xg7muu3fl2lv6j9ck6hgy0970r08pvx0rz",
+							"StakeUnits": 31262905
+						}
+					}
+				},
+				{
+					"height": 257485,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "E6B6FBC73BFD62BC36F0E236BC065FCC18D328832908C240399E2DF2E2CB6565"
+						},
+						"fields": {
+							"StakeUnits": 9702125229
+						}
+					}
+				},
+				{
+					"height": 260113,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "A6788765BCFBEC33F0F4585CB736105D4005AB81FEC30113231CF1D41F843AEA"
+						},
+						"fields": {
+							"StakeUnits": 272714488439
+						}
+					}
+				},
+				{
+					"height": 260114,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "1296D15627331C78CA5BC7CEE014C98273C5B08D358FA451C8039B42EAD61054"
+						},
+						"fields": {
+							"StakeUnits": 128877756350
+						}
+					}
+				},
+				{
+					"height": 260115,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "F6B4EDB5555CC4FAF513729D16F2D906DEC5C950DC95530F26ABFDC7ECD5DBCE"
+						},
+						"fields": {
+							"StakeUnits": 75139724801
+						}
+					}
+				},
+				{
+					"height": 260116,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "86679B5EE155F2997251108713C96AE0AC91444BFD0883A99D0611A255F0F2D7"
+						},
+						"fields": {
+							"StakeUnits": 41517402427
+						}
+					}
+				},
+				{
+					"height": 260119,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "04B6F0AEDFA9ABD9DD949541C2B7762DC2EA62026ACB39C8992482355318FB8C"
+						},
+						"fields": {
+							"StakeUnits": 29065838793
+						}
+					}
+				},
+				{
+					"height": 265159,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "2BABF243911BA2CFF2551143131985515C4873C9D6C87E44027E0F7F14E29792"
+						},
+						"fields": {
+							"StakeUnits": 18962634918
+						}
+					}
+				},
+				{
+					"height": 269611,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "CCE905915CEC65FD6FDC48E31E43E65FCD73ABDCF90A4419EFDFE7E43B63DDD0"
+						},
+						"fields": {
+							"StakeUnits": 156734300
+						}
+					}
+				},
+				{
+					"height": 271635,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "02BA91CF8F6FF3E35A1C7F0F1991BB2A2E200B78B3CF7A77DAF77E66067B205F"
+						},
+						"fields": {
+							"StakeUnits": 83041261241
+						}
+					}
+				},
+				{
+					"height": 271741,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "4B95FDF07545DF8BDD9B05982F013166E0BAB8B54F419548DEB0D3EE2E5F454E"
+						},
+						"fields": {
+							"StakeUnits": 1539766365
+						}
+					}
+				},
+				{
+					"height": 277262,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "E0E67CF364BFDD9B312C1899C60582F720A44F1A8023333F7849E0AAD0B9E4DB"
+						},
+						"fields": {
+							"StakeUnits": 9402258
+						}
+					}
+				},
+				{
+					"height": 292069,
+					"description": "Withdraw units included the impermanent loss protection units, https://gitlab.com/thorchain/thornode/-/issues/912",
+					"override": {
+						"type": "Unstake",
+						"match": {
+							"Tx": "70558EA306ADA6C6705A4C15AA60BB06D9000F75F9C2FA85153027F0AC131357"
+						},
+						"fields": {
+							"StakeUnits": 10046673124
+						}
+					}
+				}
+			]
+		},
+		{
+			"chainID": "8371BCEB807EEC52AC6A23E2FFC300D18FD3938374D3F4FC78EEB5FE33F78AF7",
+			"name": "testnet-202104",
+			"corrections": [
+				{
+					"height": 28795,
+					"description": "Withdraw 57BD5B26B0D78CD4A0340F8ECA2356B23B029157E43DE99EF03114CC15577C8A failed, still pool balances were changed, fixed in https://gitlab.com/thorchain/thornode/-/merge_requests/1634",
+					"add": {
+						"type": "PoolBalanceChange",
+						"fields": {
+							"Asset": "LTC.LTC",
+							"RuneAmt": 1985607,
+							"RuneAdd": false,
+							"AssetAmt": 93468,
+							"AssetAdd": false,
+							"Reason": "Midgard fix: Reserve didn't have rune for gas"
+						}
+					}
+				}
+			]
+		}
+	]
+}
+`
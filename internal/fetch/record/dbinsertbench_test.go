@@ -2,6 +2,7 @@ package record_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"strconv"
@@ -36,11 +37,11 @@ func insertOne(t *testing.T, n int64) {
 	}
 	height := n
 
-	const q = `INSERT INTO swap_events (tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP, liq_fee_E8, liq_fee_in_rune_E8, block_timestamp)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	const q = `INSERT INTO swap_events (tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP, liq_fee_E8, liq_fee_in_rune_E8, block_timestamp, block_height, tx_index, event_index)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
 	result, err := db.Exec(
 		q, e.Tx, e.Chain, e.FromAddr, e.ToAddr, e.FromAsset, e.FromE8, e.ToAsset, e.ToE8, e.Memo,
-		e.Pool, e.ToE8Min, e.SwapSlipBP, e.LiqFeeE8, e.LiqFeeInRuneE8, height)
+		e.Pool, e.ToE8Min, e.SwapSlipBP, e.LiqFeeE8, e.LiqFeeInRuneE8, height, height, 0, 0)
 	if err != nil {
 		t.Error("failed to insert:", err)
 		return
@@ -77,7 +78,7 @@ func valueStringIterator(argNum int) func() string {
 
 func insertBatch(t *testing.T, from, to int64) {
 	length := int(to - from)
-	argNum := 15
+	argNum := 18
 	valueStrs := make([]string, 0, length)
 	valueArgs := make([]interface{}, 0, argNum*length)
 	insertIt := valueStringIterator(argNum)
@@ -101,10 +102,10 @@ func insertBatch(t *testing.T, from, to int64) {
 		height := n
 		valueStrs = append(valueStrs, insertIt())
 		valueArgs = append(valueArgs, e.Tx, e.Chain, e.FromAddr, e.ToAddr, e.FromAsset, e.FromE8, e.ToAsset, e.ToE8, e.Memo,
-			e.Pool, e.ToE8Min, e.SwapSlipBP, e.LiqFeeE8, e.LiqFeeInRuneE8, height)
+			e.Pool, e.ToE8Min, e.SwapSlipBP, e.LiqFeeE8, e.LiqFeeInRuneE8, height, height, 0, 0)
 	}
 	q := fmt.Sprintf(
-		`INSERT INTO swap_events (tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP, liq_fee_E8, liq_fee_in_rune_E8, block_timestamp)
+		`INSERT INTO swap_events (tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP, liq_fee_E8, liq_fee_in_rune_E8, block_timestamp, block_height, tx_index, event_index)
 	VALUES %s`, strings.Join(valueStrs, ","))
 
 	result, err := db.Exec(q, valueArgs...)
@@ -122,6 +123,23 @@ func insertBatch(t *testing.T, from, to int64) {
 	}
 }
 
+// InsertBlock writes the swaps the way the recorder does, with db.Insert between Begin and
+// Commit.
+func insertBlock(t *testing.T, from, to int64) {
+	if err := db.Begin(); err != nil {
+		t.Error("failed to begin:", err)
+		return
+	}
+	for n := from; n < to; n++ {
+		db.Insert("swap_events", "tx, chain, from_addr, to_addr, from_asset, from_E8, to_asset, to_E8, memo, pool, to_E8_min, swap_slip_BP, liq_fee_E8, liq_fee_in_rune_E8, block_timestamp, block_height, tx_index, event_index",
+			intToBytes(n), []byte("chain"), intToBytes(n), intToBytes(n), []byte("BNB.BNB"), n,
+			[]byte("THOR.RUNE"), n, intToBytes(n), []byte("BNB.BNB"), n, n, n, n, n, n, 0, 0)
+	}
+	if err := db.Commit(); err != nil {
+		t.Error("failed to commit:", err)
+	}
+}
+
 func TestInsertOne(t *testing.T) {
 	testdb.SetupTestDB(t)
 	_, _ = db.Exec("DELETE FROM swap_events")
@@ -134,6 +152,28 @@ func TestInsertBatch(t *testing.T) {
 	insertBatch(t, 0, 4000)
 }
 
+func TestInsertBlock(t *testing.T) {
+	testdb.SetupTestTxDB(t)
+	_, _ = db.Exec("DELETE FROM swap_events")
+	insertBlock(t, 0, 5000)
+
+	rows, err := db.Query(context.Background(), "SELECT COUNT(*) FROM swap_events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var count int
+	if !rows.Next() {
+		t.Fatal("no count")
+	}
+	if err := rows.Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 5000 {
+		t.Errorf("got %d rows, want 5000", count)
+	}
+}
+
 func BenchmarkInsertOne(b *testing.B) {
 	testdb.SetupTestDB(nil)
 	_, _ = db.Exec("DELETE FROM swap_events")
@@ -170,3 +210,22 @@ func BenchmarkInsertBatch(b *testing.B) {
 		insertBatch(nil, int64(i), int64(to))
 	}
 }
+
+// Blocks with 100 swaps each, as written by the recorder. Compared to BenchmarkInsertOne there
+// is one statement per table and block, instead of three round trips per row (with the
+// savepoints). Compare with:
+//
+// $ go test -run=NONE -bench Insert -v -p 1 ./internal/fetch/record/
+func BenchmarkInsertBlock(b *testing.B) {
+	testdb.SetupTestTxDB(nil)
+	_, _ = db.Exec("DELETE FROM swap_events")
+	b.ResetTimer()
+	blockSize := 100
+	for i := 0; i < b.N; i += blockSize {
+		to := i + blockSize
+		if b.N < to {
+			to = b.N
+		}
+		insertBlock(nil, int64(i), int64(to))
+	}
+}
@@ -1259,6 +1259,96 @@ func (e *Unstake) LoadTendermint(attrs []abci.EventAttribute) error {
 	return nil
 }
 
+// SlashPoints defines the "slash_points" event type.
+type SlashPoints struct {
+	NodeAddr []byte // THOR address
+	Points   int64  // slash points added (or removed when negative)
+	Reason   string
+}
+
+// LoadTendermint adopts the attributes.
+func (e *SlashPoints) LoadTendermint(attrs []abci.EventAttribute) error {
+	*e = SlashPoints{}
+
+	for _, attr := range attrs {
+		var err error
+		switch string(attr.Key) {
+		case "node_address":
+			e.NodeAddr = attr.Value
+		case "slash_points":
+			e.Points, err = ParseInt(string(attr.Value))
+			if err != nil {
+				return fmt.Errorf("malformed slash_points: %w", err)
+			}
+		case "reason":
+			e.Reason = string(attr.Value)
+
+		default:
+			miderr.Printf("unknown slash_points event attribute %q=%q", attr.Key, attr.Value)
+		}
+	}
+
+	return nil
+}
+
+// TSSKeygen defines the "tss_keygen" event type, with the metric of a vault keygen.
+type TSSKeygen struct {
+	PubKey           []byte // vault public key
+	MedianDurationMs int64  // median of the keygen durations reported by the members
+}
+
+// LoadTendermint adopts the attributes.
+func (e *TSSKeygen) LoadTendermint(attrs []abci.EventAttribute) error {
+	*e = TSSKeygen{}
+
+	for _, attr := range attrs {
+		var err error
+		switch string(attr.Key) {
+		case "pubkey":
+			e.PubKey = attr.Value
+		case "median_duration_ms":
+			e.MedianDurationMs, err = ParseInt(string(attr.Value))
+			if err != nil {
+				return fmt.Errorf("malformed median_duration_ms: %w", err)
+			}
+
+		default:
+			miderr.Printf("unknown tss_keygen event attribute %q=%q", attr.Key, attr.Value)
+		}
+	}
+
+	return nil
+}
+
+// TSSKeysign defines the "tss_keysign" event type, with the metric of an outbound signature.
+type TSSKeysign struct {
+	Tx               []byte // outbound transaction identifier
+	MedianDurationMs int64  // median of the keysign durations reported by the signers
+}
+
+// LoadTendermint adopts the attributes.
+func (e *TSSKeysign) LoadTendermint(attrs []abci.EventAttribute) error {
+	*e = TSSKeysign{}
+
+	for _, attr := range attrs {
+		var err error
+		switch string(attr.Key) {
+		case "txid":
+			e.Tx = attr.Value
+		case "median_duration_ms":
+			e.MedianDurationMs, err = ParseInt(string(attr.Value))
+			if err != nil {
+				return fmt.Errorf("malformed median_duration_ms: %w", err)
+			}
+
+		default:
+			miderr.Printf("unknown tss_keysign event attribute %q=%q", attr.Key, attr.Value)
+		}
+	}
+
+	return nil
+}
+
 // UpdateNodeAccountStatus defines the "UpdateNodeAccountStatus" event type.
 type UpdateNodeAccountStatus struct {
 	NodeAddr []byte // THORChain address
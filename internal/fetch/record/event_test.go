@@ -143,6 +143,36 @@ func TestTransfer(t *testing.T) {
 	require.Equal(t, "BNB/BNB", string(event.Asset))
 }
 
+func TestSlashPoints(t *testing.T) {
+	var event SlashPoints
+	err := event.LoadTendermint(toAttrs(map[string]string{
+		"node_address": "thor1xd4j3gk9frpxh8r22runntnqy34lwzrdkazldh",
+		"slash_points": "-2",
+		"reason":       "keysign_success",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "thor1xd4j3gk9frpxh8r22runntnqy34lwzrdkazldh", string(event.NodeAddr))
+	require.Equal(t, int64(-2), event.Points)
+	require.Equal(t, "keysign_success", event.Reason)
+
+	err = event.LoadTendermint(toAttrs(map[string]string{
+		"node_address": "thor1xd4j3gk9frpxh8r22runntnqy34lwzrdkazldh",
+		"slash_points": "many",
+	}))
+	require.Error(t, err)
+}
+
+func TestTSSKeysign(t *testing.T) {
+	var event TSSKeysign
+	err := event.LoadTendermint(toAttrs(map[string]string{
+		"txid":               "B7D4E8D2B5D5F51B5C0F8C12D7E6B2BC5F5E6D9AE6E7E1C4DAF0D2B0A5C3E1F9",
+		"median_duration_ms": "1234",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "B7D4E8D2B5D5F51B5C0F8C12D7E6B2BC5F5E6D9AE6E7E1C4DAF0D2B0A5C3E1F9", string(event.Tx))
+	require.Equal(t, int64(1234), event.MedianDurationMs)
+}
+
 func toAttrs(m map[string]string) []abci.EventAttribute {
 	a := make([]abci.EventAttribute, 0, len(m))
 	for k, v := range m {
@@ -0,0 +1,270 @@
+package record
+
+// Listener gets the decoded events from the Demux, with one callback per event type. The
+// Recorder is the one which persists them. Events and metadata are reused by the Demux after
+// the callback returns, so implementations must copy what they keep.
+type Listener interface {
+	OnActiveVault(e *ActiveVault, meta *Metadata)
+	OnAdd(e *Add, meta *Metadata)
+	OnAsgardFundYggdrasil(e *AsgardFundYggdrasil, meta *Metadata)
+	OnBond(e *Bond, meta *Metadata)
+	OnErrata(e *Errata, meta *Metadata)
+	OnFee(e *Fee, meta *Metadata)
+	OnGas(e *Gas, meta *Metadata)
+	OnInactiveVault(e *InactiveVault, meta *Metadata)
+	OnMessage(e *Message, meta *Metadata)
+	OnNewNode(e *NewNode, meta *Metadata)
+	OnOutbound(e *Outbound, meta *Metadata)
+	OnPool(e *Pool, meta *Metadata)
+	OnRefund(e *Refund, meta *Metadata)
+	OnReserve(e *Reserve, meta *Metadata)
+	OnRewards(e *Rewards, meta *Metadata)
+	OnSetIPAddress(e *SetIPAddress, meta *Metadata)
+	OnSetMimir(e *SetMimir, meta *Metadata)
+	OnSetNodeKeys(e *SetNodeKeys, meta *Metadata)
+	OnSetVersion(e *SetVersion, meta *Metadata)
+	OnSlash(e *Slash, meta *Metadata)
+	OnPendingLiquidity(e *PendingLiquidity, meta *Metadata)
+	OnStake(e *Stake, meta *Metadata)
+	OnSwap(e *Swap, meta *Metadata)
+	OnTransfer(e *Transfer, meta *Metadata)
+	OnUnstake(e *Unstake, meta *Metadata)
+	OnUpdateNodeAccountStatus(e *UpdateNodeAccountStatus, meta *Metadata)
+	OnValidatorRequestLeave(e *ValidatorRequestLeave, meta *Metadata)
+	OnPoolBalanceChange(e *PoolBalanceChange, meta *Metadata)
+	OnSwitch(e *Switch, meta *Metadata)
+	OnSlashPoints(e *SlashPoints, meta *Metadata)
+	OnTSSKeygen(e *TSSKeygen, meta *Metadata)
+	OnTSSKeysign(e *TSSKeysign, meta *Metadata)
+}
+
+// NopListener ignores all events. Embed it to implement only the callbacks of interest.
+type NopListener struct{}
+
+func (NopListener) OnActiveVault(*ActiveVault, *Metadata)                         {}
+func (NopListener) OnAdd(*Add, *Metadata)                                         {}
+func (NopListener) OnAsgardFundYggdrasil(*AsgardFundYggdrasil, *Metadata)         {}
+func (NopListener) OnBond(*Bond, *Metadata)                                       {}
+func (NopListener) OnErrata(*Errata, *Metadata)                                   {}
+func (NopListener) OnFee(*Fee, *Metadata)                                         {}
+func (NopListener) OnGas(*Gas, *Metadata)                                         {}
+func (NopListener) OnInactiveVault(*InactiveVault, *Metadata)                     {}
+func (NopListener) OnMessage(*Message, *Metadata)                                 {}
+func (NopListener) OnNewNode(*NewNode, *Metadata)                                 {}
+func (NopListener) OnOutbound(*Outbound, *Metadata)                               {}
+func (NopListener) OnPool(*Pool, *Metadata)                                       {}
+func (NopListener) OnRefund(*Refund, *Metadata)                                   {}
+func (NopListener) OnReserve(*Reserve, *Metadata)                                 {}
+func (NopListener) OnRewards(*Rewards, *Metadata)                                 {}
+func (NopListener) OnSetIPAddress(*SetIPAddress, *Metadata)                       {}
+func (NopListener) OnSetMimir(*SetMimir, *Metadata)                               {}
+func (NopListener) OnSetNodeKeys(*SetNodeKeys, *Metadata)                         {}
+func (NopListener) OnSetVersion(*SetVersion, *Metadata)                           {}
+func (NopListener) OnSlash(*Slash, *Metadata)                                     {}
+func (NopListener) OnPendingLiquidity(*PendingLiquidity, *Metadata)               {}
+func (NopListener) OnStake(*Stake, *Metadata)                                     {}
+func (NopListener) OnSwap(*Swap, *Metadata)                                       {}
+func (NopListener) OnTransfer(*Transfer, *Metadata)                               {}
+func (NopListener) OnUnstake(*Unstake, *Metadata)                                 {}
+func (NopListener) OnUpdateNodeAccountStatus(*UpdateNodeAccountStatus, *Metadata) {}
+func (NopListener) OnValidatorRequestLeave(*ValidatorRequestLeave, *Metadata)     {}
+func (NopListener) OnPoolBalanceChange(*PoolBalanceChange, *Metadata)             {}
+func (NopListener) OnSwitch(*Switch, *Metadata)                                   {}
+func (NopListener) OnSlashPoints(*SlashPoints, *Metadata)                         {}
+func (NopListener) OnTSSKeygen(*TSSKeygen, *Metadata)                             {}
+func (NopListener) OnTSSKeysign(*TSSKeysign, *Metadata)                           {}
+
+// Listeners forwards each event to all elements in order.
+type listeners []Listener
+
+func (l listeners) OnActiveVault(e *ActiveVault, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnActiveVault(e, meta)
+	}
+}
+
+func (l listeners) OnAdd(e *Add, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnAdd(e, meta)
+	}
+}
+
+func (l listeners) OnAsgardFundYggdrasil(e *AsgardFundYggdrasil, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnAsgardFundYggdrasil(e, meta)
+	}
+}
+
+func (l listeners) OnBond(e *Bond, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnBond(e, meta)
+	}
+}
+
+func (l listeners) OnErrata(e *Errata, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnErrata(e, meta)
+	}
+}
+
+func (l listeners) OnFee(e *Fee, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnFee(e, meta)
+	}
+}
+
+func (l listeners) OnGas(e *Gas, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnGas(e, meta)
+	}
+}
+
+func (l listeners) OnInactiveVault(e *InactiveVault, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnInactiveVault(e, meta)
+	}
+}
+
+func (l listeners) OnMessage(e *Message, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnMessage(e, meta)
+	}
+}
+
+func (l listeners) OnNewNode(e *NewNode, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnNewNode(e, meta)
+	}
+}
+
+func (l listeners) OnOutbound(e *Outbound, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnOutbound(e, meta)
+	}
+}
+
+func (l listeners) OnPool(e *Pool, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnPool(e, meta)
+	}
+}
+
+func (l listeners) OnRefund(e *Refund, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnRefund(e, meta)
+	}
+}
+
+func (l listeners) OnReserve(e *Reserve, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnReserve(e, meta)
+	}
+}
+
+func (l listeners) OnRewards(e *Rewards, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnRewards(e, meta)
+	}
+}
+
+func (l listeners) OnSetIPAddress(e *SetIPAddress, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSetIPAddress(e, meta)
+	}
+}
+
+func (l listeners) OnSetMimir(e *SetMimir, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSetMimir(e, meta)
+	}
+}
+
+func (l listeners) OnSetNodeKeys(e *SetNodeKeys, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSetNodeKeys(e, meta)
+	}
+}
+
+func (l listeners) OnSetVersion(e *SetVersion, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSetVersion(e, meta)
+	}
+}
+
+func (l listeners) OnSlash(e *Slash, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSlash(e, meta)
+	}
+}
+
+func (l listeners) OnPendingLiquidity(e *PendingLiquidity, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnPendingLiquidity(e, meta)
+	}
+}
+
+func (l listeners) OnStake(e *Stake, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnStake(e, meta)
+	}
+}
+
+func (l listeners) OnSwap(e *Swap, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSwap(e, meta)
+	}
+}
+
+func (l listeners) OnTransfer(e *Transfer, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnTransfer(e, meta)
+	}
+}
+
+func (l listeners) OnUnstake(e *Unstake, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnUnstake(e, meta)
+	}
+}
+
+func (l listeners) OnUpdateNodeAccountStatus(e *UpdateNodeAccountStatus, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnUpdateNodeAccountStatus(e, meta)
+	}
+}
+
+func (l listeners) OnValidatorRequestLeave(e *ValidatorRequestLeave, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnValidatorRequestLeave(e, meta)
+	}
+}
+
+func (l listeners) OnPoolBalanceChange(e *PoolBalanceChange, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnPoolBalanceChange(e, meta)
+	}
+}
+
+func (l listeners) OnSwitch(e *Switch, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSwitch(e, meta)
+	}
+}
+
+func (l listeners) OnSlashPoints(e *SlashPoints, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnSlashPoints(e, meta)
+	}
+}
+
+func (l listeners) OnTSSKeygen(e *TSSKeygen, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnTSSKeygen(e, meta)
+	}
+}
+
+func (l listeners) OnTSSKeysign(e *TSSKeysign, meta *Metadata) {
+	for _, listener := range l {
+		listener.OnTSSKeysign(e, meta)
+	}
+}
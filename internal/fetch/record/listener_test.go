@@ -0,0 +1,40 @@
+package record_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+)
+
+type swapListener struct {
+	record.NopListener
+	pools   []string
+	heights []int64
+}
+
+func (l *swapListener) OnSwap(e *record.Swap, meta *record.Metadata) {
+	l.pools = append(l.pools, string(e.Pool))
+	l.heights = append(l.heights, meta.BlockHeight)
+}
+
+func TestListeners(t *testing.T) {
+	testdb.InitTest(t)
+
+	var d record.Demux
+	first, second := &swapListener{}, &swapListener{}
+	d.Listen(first)
+	d.Listen(second)
+
+	d.Block(testdb.FakeBlock(1, "2021-01-01 00:00:00",
+		testdb.PoolActivate{Pool: "BTC.BTC"},
+		testdb.Swap{Pool: "BTC.BTC", Coin: "100 BTC.BTC", EmitAsset: "200 THOR.RUNE"}))
+	d.Block(testdb.FakeBlock(2, "2021-01-01 00:00:05",
+		testdb.Swap{Pool: "ETH.ETH", Coin: "20 THOR.RUNE", EmitAsset: "10 ETH.ETH"}))
+
+	require.Equal(t, []string{"BTC.BTC", "ETH.ETH"}, first.pools)
+	require.Equal(t, []int64{1, 2}, first.heights)
+	require.Equal(t, first, second)
+}
@@ -32,10 +32,18 @@ var (
 	PoolRewardsTotal = metrics.MustCounter("midgard_pool_rewards_total", "Number of asset amounts on rewards events seen.")
 )
 
+// TxIndex values of events outside of transactions.
+const (
+	BeginBlockTxIndex = -1
+	EndBlockTxIndex   = -2
+)
+
 // Metadata has metadata for a block (from the chain).
 type Metadata struct {
 	BlockHeight    int64     // Tendermint sequence identifier
 	BlockTimestamp time.Time // official acceptance moment
+	TxIndex        int       // transaction position in the block, or BeginBlockTxIndex/EndBlockTxIndex
+	EventIndex     int       // event position in the block, over begin, transaction and end events
 }
 
 // Demux is a demultiplexer for events from the blockchain.
@@ -63,24 +71,44 @@ type Demux struct {
 		SetNodeKeys
 		SetVersion
 		Slash
+		SlashPoints
 		Stake
 		Swap
 		Transfer
+		TSSKeygen
+		TSSKeysign
 		Unstake
 		UpdateNodeAccountStatus
 		ValidatorRequestLeave
 		PoolBalanceChange
 		Switch
 	}
+
+	// Receivers of the events, with the Recorder first.
+	listeners listeners
+}
+
+// Listen adds l to the receivers of the decoded events, after the Recorder and the listeners
+// added before. Listeners are called from the goroutine of Block.
+func (d *Demux) Listen(l Listener) {
+	d.listeners = append(d.emit(), l)
+}
+
+func (d *Demux) emit() listeners {
+	if d.listeners == nil {
+		d.listeners = listeners{Recorder}
+	}
+	return d.listeners
 }
 
-// Block invokes Listener for each transaction event in block.
+// Block invokes the listeners for each transaction event in block.
 func (d *Demux) Block(block chain.Block) {
 	defer blockProcTimer.One()()
 
 	m := Metadata{
 		BlockHeight:    block.Height,
 		BlockTimestamp: block.Time,
+		TxIndex:        BeginBlockTxIndex,
 	}
 
 	// “The BeginBlock ABCI message is sent from the underlying Tendermint
@@ -94,18 +122,24 @@ func (d *Demux) Block(block chain.Block) {
 		if err := d.event(event, &m); err != nil {
 			miderr.Printf("block height %d begin event %d type %q skipped: %s",
 				block.Height, eventIndex, event.Type, err)
+			quarantine(event, &m, err)
 		}
+		m.EventIndex++
 	}
 
 	for txIndex, tx := range block.Results.TxsResults {
 		DeliverTxEventsTotal.Add(uint64(len(tx.Events)))
+		m.TxIndex = txIndex
 		for eventIndex, event := range tx.Events {
 			if err := d.event(event, &m); err != nil {
 				miderr.Printf("block height %d tx %d event %d type %q skipped: %s",
 					block.Height, txIndex, eventIndex, event.Type, err)
+				quarantine(event, &m, err)
 			}
+			m.EventIndex++
 		}
 	}
+	m.TxIndex = EndBlockTxIndex
 
 	// “The EndBlock ABCI message is sent from the underlying Tendermint
 	// engine after DeliverTx as been run for each transaction in the block.
@@ -117,7 +151,9 @@ func (d *Demux) Block(block chain.Block) {
 		if err := d.event(event, &m); err != nil {
 			miderr.Printf("block height %d end event %d type %q skipped: %s",
 				block.Height, eventIndex, event.Type, err)
+			quarantine(event, &m, err)
 		}
+		m.EventIndex++
 	}
 
 	AddMissingEvents(d, &m)
@@ -125,7 +161,7 @@ func (d *Demux) Block(block chain.Block) {
 
 var errEventType = errors.New("unknown event type")
 
-// Block notifies Listener for the transaction event.
+// Event notifies the listeners for the transaction event.
 // Errors do not include the event type in the message.
 func (d *Demux) event(event abci.Event, meta *Metadata) error {
 	defer EventProcTime(event.Type).AddSince(time.Now())
@@ -135,130 +171,130 @@ func (d *Demux) event(event abci.Event, meta *Metadata) error {
 
 	switch event.Type {
 	case "ActiveVault":
-		if err := d.reuse.ActiveVault.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.ActiveVault, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnActiveVault(&d.reuse.ActiveVault, meta)
+		d.emit().OnActiveVault(&d.reuse.ActiveVault, meta)
 	case "donate":
 		// TODO(acsaba): rename add to donate
-		if err := d.reuse.Add.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Add, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnAdd(&d.reuse.Add, meta)
+		d.emit().OnAdd(&d.reuse.Add, meta)
 	case "asgard_fund_yggdrasil":
-		if err := d.reuse.AsgardFundYggdrasil.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.AsgardFundYggdrasil, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnAsgardFundYggdrasil(&d.reuse.AsgardFundYggdrasil, meta)
+		d.emit().OnAsgardFundYggdrasil(&d.reuse.AsgardFundYggdrasil, meta)
 	case "bond":
-		if err := d.reuse.Bond.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Bond, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnBond(&d.reuse.Bond, meta)
+		d.emit().OnBond(&d.reuse.Bond, meta)
 	case "errata":
-		if err := d.reuse.Errata.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Errata, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnErrata(&d.reuse.Errata, meta)
+		d.emit().OnErrata(&d.reuse.Errata, meta)
 	case "fee":
-		if err := d.reuse.Fee.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Fee, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnFee(&d.reuse.Fee, meta)
+		d.emit().OnFee(&d.reuse.Fee, meta)
 	case "InactiveVault":
-		if err := d.reuse.InactiveVault.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.InactiveVault, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnInactiveVault(&d.reuse.InactiveVault, meta)
+		d.emit().OnInactiveVault(&d.reuse.InactiveVault, meta)
 	case "gas":
-		if err := d.reuse.Gas.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Gas, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnGas(&d.reuse.Gas, meta)
+		d.emit().OnGas(&d.reuse.Gas, meta)
 	case "message":
-		if err := d.reuse.Message.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Message, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnMessage(&d.reuse.Message, meta)
+		d.emit().OnMessage(&d.reuse.Message, meta)
 	case "new_node":
-		if err := d.reuse.NewNode.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.NewNode, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnNewNode(&d.reuse.NewNode, meta)
+		d.emit().OnNewNode(&d.reuse.NewNode, meta)
 	case "outbound":
-		if err := d.reuse.Outbound.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Outbound, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnOutbound(&d.reuse.Outbound, meta)
+		d.emit().OnOutbound(&d.reuse.Outbound, meta)
 	case "pool":
-		if err := d.reuse.Pool.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Pool, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnPool(&d.reuse.Pool, meta)
+		d.emit().OnPool(&d.reuse.Pool, meta)
 	case "refund":
-		if err := d.reuse.Refund.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Refund, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnRefund(&d.reuse.Refund, meta)
+		d.emit().OnRefund(&d.reuse.Refund, meta)
 	case "reserve":
-		if err := d.reuse.Reserve.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Reserve, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnReserve(&d.reuse.Reserve, meta)
+		d.emit().OnReserve(&d.reuse.Reserve, meta)
 	case "rewards":
-		if err := d.reuse.Rewards.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Rewards, attrs, meta); err != nil {
 			return err
 		}
 		PoolRewardsTotal.Add(uint64(len(d.reuse.Rewards.PerPool)))
-		Recorder.OnRewards(&d.reuse.Rewards, meta)
+		d.emit().OnRewards(&d.reuse.Rewards, meta)
 	case "set_ip_address":
-		if err := d.reuse.SetIPAddress.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.SetIPAddress, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSetIPAddress(&d.reuse.SetIPAddress, meta)
+		d.emit().OnSetIPAddress(&d.reuse.SetIPAddress, meta)
 	case "set_mimir":
-		if err := d.reuse.SetMimir.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.SetMimir, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSetMimir(&d.reuse.SetMimir, meta)
+		d.emit().OnSetMimir(&d.reuse.SetMimir, meta)
 	case "set_node_keys":
-		if err := d.reuse.SetNodeKeys.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.SetNodeKeys, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSetNodeKeys(&d.reuse.SetNodeKeys, meta)
+		d.emit().OnSetNodeKeys(&d.reuse.SetNodeKeys, meta)
 	case "set_version":
-		if err := d.reuse.SetVersion.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.SetVersion, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSetVersion(&d.reuse.SetVersion, meta)
+		d.emit().OnSetVersion(&d.reuse.SetVersion, meta)
 	case "slash":
-		if err := d.reuse.Slash.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Slash, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSlash(&d.reuse.Slash, meta)
+		d.emit().OnSlash(&d.reuse.Slash, meta)
 	case "pending_liquidity":
-		if err := d.reuse.PendingLiquidity.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.PendingLiquidity, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnPendingLiquidity(&d.reuse.PendingLiquidity, meta)
+		d.emit().OnPendingLiquidity(&d.reuse.PendingLiquidity, meta)
 	case "add_liquidity":
-		if err := d.reuse.Stake.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Stake, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnStake(&d.reuse.Stake, meta)
+		d.emit().OnStake(&d.reuse.Stake, meta)
 	case "swap":
-		if err := d.reuse.Swap.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Swap, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSwap(&d.reuse.Swap, meta)
+		d.emit().OnSwap(&d.reuse.Swap, meta)
 	case "transfer":
-		if err := d.reuse.Transfer.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Transfer, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnTransfer(&d.reuse.Transfer, meta)
+		d.emit().OnTransfer(&d.reuse.Transfer, meta)
 	case "withdraw":
 		// TODO(acsaba): rename unstake->withdraw.
-		if err := d.reuse.Unstake.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Unstake, attrs, meta); err != nil {
 			return err
 		}
 		if d.reuse.Unstake.StakeUnits == 0 {
@@ -268,30 +304,42 @@ func (d *Demux) event(event abci.Event, meta *Metadata) error {
 			// We need to skip those, they don't actually modify depths.
 			break
 		}
-		CorrectWithdaws(&d.reuse.Unstake, meta)
-		Recorder.OnUnstake(&d.reuse.Unstake, meta)
+		d.emit().OnUnstake(&d.reuse.Unstake, meta)
 	case "UpdateNodeAccountStatus":
-		if err := d.reuse.UpdateNodeAccountStatus.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.UpdateNodeAccountStatus, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnUpdateNodeAccountStatus(&d.reuse.UpdateNodeAccountStatus, meta)
+		d.emit().OnUpdateNodeAccountStatus(&d.reuse.UpdateNodeAccountStatus, meta)
 	case "validator_request_leave":
-		if err := d.reuse.ValidatorRequestLeave.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.ValidatorRequestLeave, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnValidatorRequestLeave(&d.reuse.ValidatorRequestLeave, meta)
+		d.emit().OnValidatorRequestLeave(&d.reuse.ValidatorRequestLeave, meta)
 	case "pool_balance_change":
-		if err := d.reuse.PoolBalanceChange.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.PoolBalanceChange, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnPoolBalanceChange(&d.reuse.PoolBalanceChange, meta)
+		d.emit().OnPoolBalanceChange(&d.reuse.PoolBalanceChange, meta)
 	case "switch":
-		if err := d.reuse.Switch.LoadTendermint(attrs); err != nil {
+		if err := load(&d.reuse.Switch, attrs, meta); err != nil {
+			return err
+		}
+		d.emit().OnSwitch(&d.reuse.Switch, meta)
+	case "slash_points":
+		if err := load(&d.reuse.SlashPoints, attrs, meta); err != nil {
 			return err
 		}
-		Recorder.OnSwitch(&d.reuse.Switch, meta)
-	case "tss_keygen", "tss_keysign", "slash_points":
-		// TODO(acsaba): decide if we want to store these events.
+		d.emit().OnSlashPoints(&d.reuse.SlashPoints, meta)
+	case "tss_keygen":
+		if err := load(&d.reuse.TSSKeygen, attrs, meta); err != nil {
+			return err
+		}
+		d.emit().OnTSSKeygen(&d.reuse.TSSKeygen, meta)
+	case "tss_keysign":
+		if err := load(&d.reuse.TSSKeysign, attrs, meta); err != nil {
+			return err
+		}
+		d.emit().OnTSSKeysign(&d.reuse.TSSKeysign, meta)
 	default:
 		miderr.Printf("Unkown event type: %s, attributes: %s",
 			event.Type, FormatAttributes(attrs))
@@ -301,6 +349,19 @@ func (d *Demux) event(event abci.Event, meta *Metadata) error {
 	return nil
 }
 
+type loader interface {
+	LoadTendermint(attrs []abci.EventAttribute) error
+}
+
+// Load adopts the attributes into e, and applies the corrections for it.
+func load(e loader, attrs []abci.EventAttribute, meta *Metadata) error {
+	if err := e.LoadTendermint(attrs); err != nil {
+		return err
+	}
+	applyOverrides(e, meta)
+	return nil
+}
+
 func FormatAttributes(attrs []abci.EventAttribute) string {
 	buf := bytes.Buffer{}
 	fmt.Fprint(&buf, "{")
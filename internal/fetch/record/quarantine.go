@@ -0,0 +1,115 @@
+package record
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
+)
+
+// Events which can't be recorded go to the quarantined_events table, with the reason. These
+// are events of an unknown type and events which fail to parse.
+
+const quarantineColumns = "block_height, tx_index, event_index, type, attributes, error, block_timestamp"
+
+// QuarantinedEvent is an event from the quarantine.
+type QuarantinedEvent struct {
+	Height     int64       `json:"height"`
+	Timestamp  db.Nano     `json:"timestamp"`
+	TxIndex    int         `json:"txIndex"`
+	EventIndex int         `json:"eventIndex"`
+	Type       string      `json:"type"`
+	Attributes []Attribute `json:"attributes"`
+	Error      string      `json:"error"`
+}
+
+// Attribute is an event attribute in the quarantine.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Quarantine keeps an event which was not processed.
+func quarantine(event abci.Event, meta *Metadata, reason error) {
+	attrs := make([]Attribute, len(event.Attributes))
+	for i, attr := range event.Attributes {
+		attrs[i] = Attribute{Key: string(attr.Key), Value: string(attr.Value)}
+	}
+	attrsJSON, err := json.Marshal(attrs)
+	if err != nil {
+		miderr.Printf("block height %d event %d type %q lost from quarantine on %s",
+			meta.BlockHeight, meta.EventIndex, event.Type, err)
+		return
+	}
+	db.Insert("quarantined_events", quarantineColumns,
+		meta.BlockHeight, meta.TxIndex, meta.EventIndex, event.Type, string(attrsJSON),
+		reason.Error(), meta.BlockTimestamp.UnixNano())
+}
+
+// QuarantinedEvents gets the events in the quarantine, in chain order. An empty eventType
+// matches all.
+func QuarantinedEvents(ctx context.Context, eventType string, limit int) ([]QuarantinedEvent, error) {
+	q := `SELECT ` + quarantineColumns + `
+	FROM quarantined_events
+	WHERE $1 = '' OR type = $1
+	ORDER BY block_height, event_index
+	LIMIT $2`
+	rows, err := db.Query(ctx, q, eventType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("quarantine lookup: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []QuarantinedEvent
+	for rows.Next() {
+		var e QuarantinedEvent
+		var attrsJSON []byte
+		err := rows.Scan(&e.Height, &e.TxIndex, &e.EventIndex, &e.Type, &attrsJSON,
+			&e.Error, &e.Timestamp)
+		if err != nil {
+			return ret, fmt.Errorf("quarantine retrieve: %w", err)
+		}
+		if err := json.Unmarshal(attrsJSON, &e.Attributes); err != nil {
+			return ret, fmt.Errorf("quarantine attributes of height %d event %d: %w",
+				e.Height, e.EventIndex, err)
+		}
+		ret = append(ret, e)
+	}
+	return ret, rows.Err()
+}
+
+// Reprocess passes the quarantined event to the listeners again, e.g. after the parser got
+// support for it. On success the event is removed from the quarantine, otherwise it gets the
+// new error. Use between db.Begin and db.Commit.
+func (d *Demux) Reprocess(e *QuarantinedEvent) error {
+	event := abci.Event{Type: e.Type, Attributes: make([]abci.EventAttribute, len(e.Attributes))}
+	for i, attr := range e.Attributes {
+		event.Attributes[i] = abci.EventAttribute{Key: []byte(attr.Key), Value: []byte(attr.Value), Index: true}
+	}
+	meta := Metadata{
+		BlockHeight:    e.Height,
+		BlockTimestamp: time.Unix(0, int64(e.Timestamp)),
+		TxIndex:        e.TxIndex,
+		EventIndex:     e.EventIndex,
+	}
+
+	processErr := d.event(event, &meta)
+
+	var err error
+	if processErr == nil {
+		_, err = db.Exec(`DELETE FROM quarantined_events
+		WHERE block_height = $1 AND event_index = $2`, e.Height, e.EventIndex)
+	} else {
+		_, err = db.Exec(`UPDATE quarantined_events SET error = $3
+		WHERE block_height = $1 AND event_index = $2`, e.Height, e.EventIndex, processErr.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return processErr
+}
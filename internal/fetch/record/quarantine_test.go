@@ -0,0 +1,45 @@
+package record_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+)
+
+func TestQuarantine(t *testing.T) {
+	testdb.InitTest(t)
+
+	var d record.Demux
+	d.Block(testdb.FakeBlock(1, "2021-01-01 00:00:00",
+		testdb.PoolActivate{Pool: "BTC.BTC"},
+		testdb.RawEvent{Type: "no_such_type", Attributes: map[string]string{"answer": "42"}},
+		testdb.RawEvent{Type: "slash_points", Attributes: map[string]string{"slash_points": "many"}}))
+
+	events, err := record.QuarantinedEvents(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, int64(1), events[0].Height)
+	require.Equal(t, record.EndBlockTxIndex, events[0].TxIndex)
+	require.Equal(t, 1, events[0].EventIndex)
+	require.Equal(t, "no_such_type", events[0].Type)
+	require.Equal(t, []record.Attribute{{Key: "answer", Value: "42"}}, events[0].Attributes)
+
+	require.Equal(t, 2, events[1].EventIndex)
+	require.Equal(t, "slash_points", events[1].Type)
+	require.Contains(t, events[1].Error, "malformed slash_points")
+
+	filtered, err := record.QuarantinedEvents(context.Background(), "slash_points", 10)
+	require.NoError(t, err)
+	require.Equal(t, events[1:], filtered)
+
+	// still unknown, stays in the quarantine
+	require.Error(t, d.Reprocess(&events[0]))
+	events, err = record.QuarantinedEvents(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+}
@@ -40,12 +40,7 @@ func (r *poolResolver) Price(ctx context.Context, obj *model.Pool) (float64, err
 }
 
 func (r *poolResolver) Units(ctx context.Context, obj *model.Pool) (int64, error) {
-	poolUnits, err := stat.CurrentPoolsLiquidityUnits(ctx, []string{obj.Asset})
-	if err != nil {
-		return 0, err
-	}
-
-	return poolUnits[obj.Asset], nil
+	return timeseries.Latest.GetState().PoolUnits[obj.Asset], nil
 }
 
 // TODO(donfrigo) add memoization layer to cache requests
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -189,13 +188,6 @@ func GetActions(ctx context.Context, moment time.Time, params ActionsParams) (
 
 	// PROCESS RESULTS
 	actions := []action{}
-	// TODO(elfedy): This is a hack to get block heights in a semi-performant way,
-	// where we get min and max timestamp and get all the relevant heights
-	// If we want to make this operation faster we should consider indexing
-	// the block_log table by timestamp or making it an hypertable
-	var minTimestamp, maxTimestamp int64
-	minTimestamp = math.MaxInt64
-
 	for rows.Next() {
 		var result actionQueryResult
 		err := rows.Scan(
@@ -220,7 +212,15 @@ func GetActions(ctx context.Context, moment time.Time, params ActionsParams) (
 			&result.emitRuneE8,
 			&result.text,
 			&result.eventType,
-			&result.blockTimestamp)
+			&result.memoAction,
+			&result.memoAsset,
+			&result.memoAddr,
+			&result.memoLimitE8,
+			&result.memoAffiliateAddr,
+			&result.memoAffiliateBP,
+			&result.blockTimestamp,
+			&result.blockHeight,
+			&result.eventIndex)
 		if err != nil {
 			return oapigen.ActionsResponse{}, fmt.Errorf("tx resolve: %w", err)
 		}
@@ -230,40 +230,9 @@ func GetActions(ctx context.Context, moment time.Time, params ActionsParams) (
 			return oapigen.ActionsResponse{}, fmt.Errorf("tx resolve: %w", err)
 		}
 
-		// compute min/max timestamp to get heights later
-		if action.date < minTimestamp {
-			minTimestamp = action.date
-		}
-		if action.date > maxTimestamp {
-			maxTimestamp = action.date
-		}
-
 		actions = append(actions, action)
 	}
 
-	// get heights and store them in a map
-	heights := make(map[int64]int64)
-	heightsQuery := "SELECT timestamp, height FROM block_log WHERE TIMESTAMP >= $1 AND TIMESTAMP <= $2"
-	heightRows, err := db.Query(ctx, heightsQuery, minTimestamp, maxTimestamp)
-	if err != nil {
-		return oapigen.ActionsResponse{}, fmt.Errorf("tx height lookup: %w", err)
-	}
-	defer heightRows.Close()
-
-	for heightRows.Next() {
-		var timestamp, height int64
-		err = heightRows.Scan(&timestamp, &height)
-		if err != nil {
-			return oapigen.ActionsResponse{}, fmt.Errorf("tx height resolve: %w", err)
-		}
-		heights[timestamp] = height
-	}
-
-	// Add height to each result set
-	for i := range actions {
-		actions[i].height = heights[actions[i].date]
-	}
-
 	oapigenActions := make([]oapigen.Action, len(actions))
 	for i, action := range actions {
 		oapigenActions[i] = action.toOapigen()
@@ -373,7 +342,7 @@ func actionsPreparedStatemets(moment time.Time,
 
 	// build subset query for the results being shown (based on limit and offset)
 	subsetQuery := `
-	ORDER BY union_results.block_timestamp DESC
+	ORDER BY union_results.block_height DESC, union_results.event_index DESC
 	LIMIT #LIMIT#
 	OFFSET #OFFSET#
 	`
@@ -425,7 +394,16 @@ type actionQueryResult struct {
 	emitRuneE8     int64
 	text           string
 	eventType      string
-	blockTimestamp int64
+	// parsed memo, of swaps, donates and withdraws only
+	memoAction        sql.NullString
+	memoAsset         sql.NullString
+	memoAddr          sql.NullString
+	memoLimitE8       sql.NullInt64
+	memoAffiliateAddr sql.NullString
+	memoAffiliateBP   sql.NullInt64
+	blockTimestamp    int64
+	blockHeight       int64
+	eventIndex        int64
 }
 
 func actionProcessQueryResult(ctx context.Context, result actionQueryResult) (action, error) {
@@ -597,9 +575,21 @@ func actionProcessQueryResult(ctx context.Context, result actionQueryResult) (ac
 		}
 	}
 
+	if result.memoAction.Valid {
+		metadata.Memo = &oapigen.MemoMetadata{
+			Action:               result.memoAction.String,
+			Asset:                result.memoAsset.String,
+			Address:              result.memoAddr.String,
+			Limit:                util.IntStr(result.memoLimitE8.Int64),
+			AffiliateAddress:     result.memoAffiliateAddr.String,
+			AffiliateBasisPoints: util.IntStr(result.memoAffiliateBP.Int64),
+		}
+	}
+
 	action := action{
 		eventType: result.eventType,
 		date:      result.blockTimestamp,
+		height:    result.blockHeight,
 		metadata:  metadata,
 		in:        inTxs,
 		out:       outTxs,
@@ -735,10 +725,18 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			'' as text,
 			'swap' as type,
-			block_timestamp
+			memo_action,
+			memo_asset,
+			memo_addr,
+			memo_limit_E8,
+			memo_affiliate_addr,
+			memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM swap_events AS single_swaps
 		WHERE NOT EXISTS (
-			SELECT tx FROM swap_events WHERE block_timestamp = single_swaps.block_timestamp AND tx = single_swaps.tx AND from_asset <> single_swaps.from_asset
+			SELECT tx FROM swap_events WHERE block_height = single_swaps.block_height AND tx = single_swaps.tx AND from_asset <> single_swaps.from_asset
 		)`,
 		// Double swap (same txid in different pools)
 		`SELECT
@@ -764,13 +762,21 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			'' as text,
 			'swap' as type,
-			swap_in.block_timestamp as block_timestamp
+			swap_in.memo_action as memo_action,
+			swap_in.memo_asset as memo_asset,
+			swap_in.memo_addr as memo_addr,
+			swap_in.memo_limit_E8 as memo_limit_E8,
+			swap_in.memo_affiliate_addr as memo_affiliate_addr,
+			swap_in.memo_affiliate_BP as memo_affiliate_BP,
+			swap_in.block_timestamp as block_timestamp,
+			swap_in.block_height as block_height,
+			swap_in.event_index as event_index
 		FROM
 		swap_events AS swap_in
 		INNER JOIN
 		swap_events AS swap_out
 		ON swap_in.tx = swap_out.tx
-		WHERE swap_in.from_asset = swap_in.pool AND swap_out.from_asset <> swap_out.pool AND swap_in.block_timestamp = swap_out.block_timestamp`,
+		WHERE swap_in.from_asset = swap_in.pool AND swap_out.from_asset <> swap_out.pool AND swap_in.block_height = swap_out.block_height`,
 	},
 	"addLiquidity": {
 		// Get liquidity already added to the pools
@@ -796,7 +802,15 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			'' as text,
 			'addLiquidity' as type,
-			block_timestamp
+			NULL as memo_action,
+			NULL as memo_asset,
+			NULL as memo_addr,
+			NULL as memo_limit_E8,
+			NULL as memo_affiliate_addr,
+			NULL as memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM stake_events`,
 		// Get pending liquidity, it will be added when the other asset arrives.
 		// There is no partial addition or withdraw of pending liquidity. Once a corresponding
@@ -824,7 +838,15 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			'pending' as text,
 			'addLiquidity' as type,
-			block_timestamp
+			NULL as memo_action,
+			NULL as memo_asset,
+			NULL as memo_addr,
+			NULL as memo_limit_E8,
+			NULL as memo_affiliate_addr,
+			NULL as memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM pending_liquidity_events AS p
 		WHERE pending_type = 'add'
 			AND NOT EXISTS(SELECT *
@@ -864,7 +886,15 @@ var txInSelectQueries = map[string][]string{
 			emit_rune_E8,
 			'' as text,
 			'withdraw' as type,
-			block_timestamp
+			memo_action,
+			memo_asset,
+			memo_addr,
+			memo_limit_E8,
+			memo_affiliate_addr,
+			memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM unstake_events`,
 	},
 	"donate": {
@@ -890,7 +920,15 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			'' as text,
 			'donate' as type,
-			block_timestamp
+			memo_action,
+			memo_asset,
+			memo_addr,
+			memo_limit_E8,
+			memo_affiliate_addr,
+			memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM add_events`,
 	},
 	"refund": {
@@ -916,7 +954,15 @@ var txInSelectQueries = map[string][]string{
 			0 as emit_rune_E8,
 			reason as text,
 			'refund' as type,
-			block_timestamp
+			NULL as memo_action,
+			NULL as memo_asset,
+			NULL as memo_addr,
+			NULL as memo_limit_E8,
+			NULL as memo_affiliate_addr,
+			NULL as memo_affiliate_BP,
+			block_timestamp,
+			block_height,
+			event_index
 		FROM refund_events`,
 	},
 	"switch": {
@@ -942,7 +988,15 @@ var txInSelectQueries = map[string][]string{
 				0 as emit_rune_E8,
 				'' as text,
 				'switch' as type,
-				block_timestamp
+				NULL as memo_action,
+				NULL as memo_asset,
+				NULL as memo_addr,
+				NULL as memo_limit_E8,
+				NULL as memo_affiliate_addr,
+				NULL as memo_affiliate_BP,
+				block_timestamp,
+				block_height,
+				event_index
 			FROM switch_events`,
 	},
 }
@@ -461,3 +461,43 @@ func TestAffiliateFee(t *testing.T) {
 		checkFilter(t, "", []string{})
 	})
 }
+
+func TestSwapMemoMetadata(t *testing.T) {
+	blocks := testdb.InitTestBlocks(t)
+
+	blocks.NewBlock(t, "2020-09-01 00:00:00",
+		testdb.Swap{
+			Coin:      "100000 BNB.BNB",
+			EmitAsset: "10 THOR.RUNE",
+			Pool:      "BNB.BNB",
+			TxID:      "TX1",
+			Memo:      "=:THOR.RUNE:thoraddr1:8:thoraff:15",
+		},
+		testdb.Swap{
+			Coin:      "100000 BNB.BNB",
+			EmitAsset: "10 THOR.RUNE",
+			Pool:      "BNB.BNB",
+			TxID:      "TX2",
+		},
+		testdb.PoolActivate{Pool: "BNB.BNB"},
+	)
+
+	body := testdb.CallJSON(t, "http://localhost:8080/v2/actions?limit=50&offset=0&txid=TX1")
+	var v oapigen.ActionsResponse
+	testdb.MustUnmarshal(t, body, &v)
+	require.Len(t, v.Actions, 1)
+	require.Equal(t, &oapigen.MemoMetadata{
+		Action:               "swap",
+		Asset:                "THOR.RUNE",
+		Address:              "thoraddr1",
+		Limit:                "8",
+		AffiliateAddress:     "thoraff",
+		AffiliateBasisPoints: "15",
+	}, v.Actions[0].Metadata.Memo)
+
+	// "doesntmatter" is no memo
+	body = testdb.CallJSON(t, "http://localhost:8080/v2/actions?limit=50&offset=0&txid=TX2")
+	testdb.MustUnmarshal(t, body, &v)
+	require.Len(t, v.Actions, 1)
+	require.Nil(t, v.Actions[0].Metadata.Memo)
+}
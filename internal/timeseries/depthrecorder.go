@@ -1,5 +1,5 @@
 // Depth recorder fills keeps track of historical depth values and inserts changes
-// in the block_pool_depths table.
+// in the block_pool_depths table. Units recorder does the same for block_pool_units.
 package timeseries
 
 import (
@@ -34,21 +34,44 @@ func (md *mapDiff) diffAtKey(pool string, newMap map[string]int64) (hasDiff bool
 	}
 }
 
-type depthManager struct {
-	assetE8DepthSnapshot mapDiff
-	runeE8DepthSnapshot  mapDiff
+// SparseRecorder writes the values of the pools to table, with a row only for the pools with
+// a changed value since the last update.
+type sparseRecorder struct {
+	table string
+	// Value columns, one per map of update.
+	columns []string
+	// What the values are, for the errors.
+	name      string
+	snapshots []mapDiff
 }
 
-var depthRecorder depthManager
+var depthRecorder = sparseRecorder{
+	table:   "block_pool_depths",
+	columns: []string{"asset_e8", "rune_e8"},
+	name:    "depths",
+}
+
+var unitsRecorder = sparseRecorder{
+	table:   "block_pool_units",
+	columns: []string{"units"},
+	name:    "units",
+}
 
-// Insert rows in the block_pool_depths for every changed value in the depth maps.
+// Insert rows in the table for every changed value in the maps, one map per column.
 // If there is no change it doesn't write out anything.
-// Both values will be writen out together (assetDepth, runeDepth), even if only one of the values
-// changed in the pool.
-func (sm *depthManager) update(
-	timestamp time.Time, assetE8DepthPerPool, runeE8DepthPerPool map[string]int64) error {
+// All values of a pool will be writen out together (e.g. assetDepth, runeDepth), even if only
+// one of the values changed in the pool.
+func (sr *sparseRecorder) update(timestamp time.Time, valuesPerPool ...map[string]int64) error {
+	if len(valuesPerPool) != len(sr.columns) {
+		return fmt.Errorf("%d value maps for the %d columns of %s",
+			len(valuesPerPool), len(sr.columns), sr.table)
+	}
+	if sr.snapshots == nil {
+		sr.snapshots = make([]mapDiff, len(sr.columns))
+	}
+
 	blockTimestamp := timestamp.UnixNano()
-	// We need to iterate over all 4 maps: oldAssets, newAssets, oldRunes, newRunes.
+	// We need to iterate over all old and new maps.
 	// First put all pool names into a set.
 	poolNames := map[string]bool{}
 	accumulatePoolNames := func(m map[string]int64) {
@@ -56,49 +79,59 @@ func (sm *depthManager) update(
 			poolNames[pool] = true
 		}
 	}
-	accumulatePoolNames(assetE8DepthPerPool)
-	accumulatePoolNames(runeE8DepthPerPool)
-	accumulatePoolNames(sm.assetE8DepthSnapshot.snapshot)
-	accumulatePoolNames(sm.runeE8DepthSnapshot.snapshot)
+	for i, m := range valuesPerPool {
+		accumulatePoolNames(m)
+		accumulatePoolNames(sr.snapshots[i].snapshot)
+	}
 
-	queryFront := "INSERT INTO block_pool_depths (block_timestamp, pool, asset_e8, rune_e8) VALUES "
-	queryEnd := " ON CONFLICT DO NOTHING;"
-	rowFormat := "($%d, $%d, $%d, $%d)"
+	width := len(sr.columns) + 2
 	rowStrs := []string{}
-	values := []interface{}{} // Finally there will be rowNum*4 parameters.
+	values := []interface{}{} // Finally there will be rowNum*width parameters.
+	params := make([]string, width)
+	row := make([]interface{}, len(sr.columns))
 
 	for pool := range poolNames {
-		assetDiff, assetValue := sm.assetE8DepthSnapshot.diffAtKey(pool, assetE8DepthPerPool)
-		runeDiff, runeValue := sm.runeE8DepthSnapshot.diffAtKey(pool, runeE8DepthPerPool)
-		if assetDiff || runeDiff {
-			p := len(values)
-			rowStrs = append(rowStrs, fmt.Sprintf(rowFormat, p+1, p+2, p+3, p+4))
-			values = append(values, blockTimestamp, pool, assetValue, runeValue)
+		changed := false
+		for i, m := range valuesPerPool {
+			diff, value := sr.snapshots[i].diffAtKey(pool, m)
+			changed = changed || diff
+			row[i] = value
 		}
+		if changed {
+			for i := range params {
+				params[i] = fmt.Sprintf("$%d", len(values)+i+1)
+			}
+			rowStrs = append(rowStrs, "("+strings.Join(params, ", ")+")")
+			values = append(values, blockTimestamp, pool)
+			values = append(values, row...)
+		}
+	}
+	for i, m := range valuesPerPool {
+		sr.snapshots[i].save(m)
 	}
-	sm.assetE8DepthSnapshot.save(assetE8DepthPerPool)
-	sm.runeE8DepthSnapshot.save(runeE8DepthPerPool)
 
 	diffNum := len(rowStrs)
 
 	if 0 == diffNum {
-		// There were no differences in depths.
+		// There were no differences.
 		return nil
 	}
 
-	query := queryFront + strings.Join(rowStrs, ", ") + queryEnd
+	query := "INSERT INTO " + sr.table + " (block_timestamp, pool, " +
+		strings.Join(sr.columns, ", ") + ") VALUES " +
+		strings.Join(rowStrs, ", ") + " ON CONFLICT DO NOTHING;"
 	result, err := db.Exec(query, values...)
 	if err != nil {
-		return fmt.Errorf("Error saving depths (timestamp: %d): %w", blockTimestamp, err)
+		return fmt.Errorf("Error saving %s (timestamp: %d): %w", sr.name, blockTimestamp, err)
 	}
 	n, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("Error on saving depths (timestamp: %d): %w", blockTimestamp, err)
+		return fmt.Errorf("Error on saving %s (timestamp: %d): %w", sr.name, blockTimestamp, err)
 	}
 	if n != int64(diffNum) {
 		return fmt.Errorf(
-			"Not all depths were saved for timestamp %d (expected inserts: %d, actual: %d)",
-			blockTimestamp, n, diffNum)
+			"Not all %s were saved for timestamp %d (expected inserts: %d, actual: %d)",
+			sr.name, blockTimestamp, diffNum, n)
 	}
 	return nil
 }
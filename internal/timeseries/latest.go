@@ -34,6 +34,8 @@ type BlockState struct {
 	Height    int64
 	Timestamp db.Nano
 	Pools     DepthMap
+	// Net liquidity units per pool.
+	PoolUnits map[string]int64
 }
 
 func (s BlockState) PoolExists(pool string) bool {
@@ -62,6 +64,7 @@ func (latest *LatestState) setLatestStates(track *blockTrack) {
 		Height:    track.Height,
 		Timestamp: db.TimeToNano(track.Timestamp),
 		Pools:     DepthMap{},
+		PoolUnits: track.UnitsPerPool,
 	}
 
 	runeDepths := track.RuneE8DepthPerPool
@@ -20,14 +20,15 @@ import (
 // ErrBeyondLast denies a request into the future.
 var errBeyondLast = errors.New("cannot resolve beyond the last block (timestamp)")
 
-// LastChurnHeight gets the latest block where a vault was activated
-func LastChurnHeight(ctx context.Context) (int64, error) {
+// LastChurnHeight gets the latest block until moment where a vault was activated
+func LastChurnHeight(ctx context.Context, moment db.Nano) (int64, error) {
 	q := `SELECT bl.height
 	FROM active_vault_events av
 	INNER JOIN block_log bl ON av.block_timestamp = bl.timestamp
+	WHERE av.block_timestamp <= $1
 	ORDER BY av.block_timestamp DESC LIMIT 1;
 	`
-	rows, err := db.Query(ctx, q)
+	rows, err := db.Query(ctx, q, moment)
 	if err != nil {
 		return 0, err
 	}
@@ -67,6 +68,65 @@ func PoolsWithDeposit(ctx context.Context) ([]string, error) {
 	return pools, rows.Err()
 }
 
+// PoolsWithDepositAt gets the asset identifiers that have at least one stake until moment
+func PoolsWithDepositAt(ctx context.Context, moment db.Nano) ([]string, error) {
+	const q = "SELECT pool FROM stake_events WHERE block_timestamp <= $1 GROUP BY pool"
+	rows, err := db.Query(ctx, q, moment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return pools, err
+		}
+		pools = append(pools, s)
+	}
+	return pools, rows.Err()
+}
+
+// BlockAtHeight gets the timestamp of the block at height.
+func BlockAtHeight(ctx context.Context, height int64) (db.Nano, error) {
+	lastHeight, _, _ := LastBlock()
+	if lastHeight < height {
+		return 0, miderr.BadRequestF("Height %d is beyond the last block %d", height, lastHeight)
+	}
+	var timestamp db.Nano
+	err := QueryOneValue(&timestamp, ctx,
+		"SELECT COALESCE(MAX(timestamp), 0) FROM block_log WHERE height = $1", height)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp == 0 {
+		return 0, miderr.BadRequestF("No block at height %d", height)
+	}
+	return timestamp, nil
+}
+
+// BlockAtTime gets the last block at or before the timestamp.
+func BlockAtTime(ctx context.Context, moment db.Nano) (height int64, timestamp db.Nano, err error) {
+	q := `
+		SELECT height, timestamp
+		FROM block_log
+		WHERE timestamp <= $1
+		ORDER BY timestamp DESC LIMIT 1`
+	rows, err := db.Query(ctx, q, moment)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		err = miderr.BadRequestF("No block until timestamp %d", moment.ToSecond())
+		return
+	}
+	err = rows.Scan(&height, &timestamp)
+	return
+}
+
 const DefaultPoolStatus = "staged"
 
 // Returns last status change for pool for a given point in time (UnixNano timestamp)
@@ -183,17 +243,17 @@ func TotalLiquidityFeesRune(ctx context.Context, from time.Time, to time.Time) (
 	return liquidityFees, nil
 }
 
-//  Get value from Mimir overrides or from the Thorchain constants.
-func GetLastConstantValue(ctx context.Context, key string) (int64, error) {
+//  Get value at moment from Mimir overrides or from the Thorchain constants.
+func GetLastConstantValue(ctx context.Context, key string, moment db.Nano) (int64, error) {
 	// TODO(elfedy): This looks at the last time the mimir value was set. This may not be
 	// the latest value (i.e: Does Thorchain send an event with the value in constants if mimir
 	// override is unset?). The logic behind this needs to be investigated further.
 	q := `SELECT CAST (value AS INTEGER)
 	FROM set_mimir_events
-	WHERE key ILIKE $1
+	WHERE key ILIKE $1 AND block_timestamp <= $2
 	ORDER BY block_timestamp DESC
 	LIMIT 1`
-	rows, err := db.Query(ctx, q, key)
+	rows, err := db.Query(ctx, q, key, moment)
 	defer rows.Close()
 	if err != nil {
 		return 0, err
@@ -327,19 +387,33 @@ WHERE block_timestamp <= $1`
 }
 
 func GetNetworkData(ctx context.Context) (model.Network, error) {
-	// GET DATA
 	// in memory lookups
+	_, runeE8DepthPerPool, timestamp := AssetAndRuneDepths()
+	currentHeight, _, _ := LastBlock()
+	return networkDataAt(ctx, 0, currentHeight, db.TimeToNano(timestamp), runeE8DepthPerPool)
+}
+
+// GetNetworkDataAt is GetNetworkData as of the block at height, with the rune depths of that
+// block. The nodes and the reserve are looked up from Thornode at the height.
+func GetNetworkDataAt(ctx context.Context, height int64, timestamp db.Nano,
+	runeE8DepthPerPool map[string]int64) (model.Network, error) {
+	return networkDataAt(ctx, height, height, timestamp, runeE8DepthPerPool)
+}
+
+// Thornode is queried at thornodeHeight, 0 is the latest.
+func networkDataAt(ctx context.Context, thornodeHeight, currentHeight int64, moment db.Nano,
+	runeE8DepthPerPool map[string]int64) (model.Network, error) {
+	// GET DATA
 	var result model.Network
 
-	_, runeE8DepthPerPool, timestamp := AssetAndRuneDepths()
 	var runeDepth int64
 	for _, depth := range runeE8DepthPerPool {
 		runeDepth += depth
 	}
-	currentHeight, _, _ := LastBlock()
+	timestamp := time.Unix(0, moment.ToI())
 
 	// db lookups
-	lastChurnHeight, err := LastChurnHeight(ctx)
+	lastChurnHeight, err := LastChurnHeight(ctx, moment)
 	if err != nil {
 		return result, err
 	}
@@ -350,33 +424,33 @@ func GetNetworkData(ctx context.Context) (model.Network, error) {
 	}
 
 	// Thorchain constants
-	emissionCurve, err := GetLastConstantValue(ctx, "EmissionCurve")
+	emissionCurve, err := GetLastConstantValue(ctx, "EmissionCurve", moment)
 	if err != nil {
 		return result, err
 	}
-	blocksPerYear, err := GetLastConstantValue(ctx, "BlocksPerYear")
+	blocksPerYear, err := GetLastConstantValue(ctx, "BlocksPerYear", moment)
 	if err != nil {
 		return result, err
 	}
-	churnInterval, err := GetLastConstantValue(ctx, "ChurnInterval")
+	churnInterval, err := GetLastConstantValue(ctx, "ChurnInterval", moment)
 	if err != nil {
 		return result, err
 	}
-	churnRetryInterval, err := GetLastConstantValue(ctx, "ChurnRetryInterval")
+	churnRetryInterval, err := GetLastConstantValue(ctx, "ChurnRetryInterval", moment)
 	if err != nil {
 		return result, err
 	}
-	poolCycle, err := GetLastConstantValue(ctx, "PoolCycle")
+	poolCycle, err := GetLastConstantValue(ctx, "PoolCycle", moment)
 	if err != nil {
 		return result, err
 	}
 
 	// Thornode queries
-	nodes, err := notinchain.NodeAccountsLookup()
+	nodes, err := notinchain.NodeAccountsLookupAt(thornodeHeight)
 	if err != nil {
 		return result, err
 	}
-	networkData, err := notinchain.NetworkLookup()
+	networkData, err := notinchain.NetworkLookupAt(thornodeHeight)
 	if err != nil {
 		return result, err
 	}
@@ -161,6 +161,8 @@ func TestPoolE2E(t *testing.T) {
 
 	testdb.InsertStakeEvent(t, testdb.FakeStake{Pool: "BNB.TWT-123", BlockTimestamp: "2020-09-01 00:00:00", StakeUnits: 80})
 	testdb.InsertUnstakeEvent(t, testdb.FakeUnstake{Pool: "BNB.TWT-123", Asset: "BNB.TWT-123", BlockTimestamp: "2020-09-01 00:00:00", StakeUnits: 30})
+	// units are tracked in memory, as the recorder would for the events above
+	timeseries.SetPoolUnitsForTest("BNB.TWT-123", 80-30)
 
 	queryString := `{
 		pool(asset: "BNB.TWT-123") {
@@ -234,3 +236,45 @@ func TestGenesisNodeGoesOut(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(2), n)
 }
+
+func TestPoolAsOfE2E(t *testing.T) {
+	testdb.InitTest(t)
+	timeseries.SetLastTimeForTest(testdb.StrToSec("2020-09-02 00:00:00"))
+	timeseries.SetLastHeightForTest(2)
+	timeseries.SetDepthsForTest([]timeseries.Depth{{"BNB.TWT-123", 10, 30}})
+	timeseries.SetPoolUnitsForTest("BNB.TWT-123", 30)
+
+	testdb.InsertBlockLog(t, 1, "2020-09-01 00:00:00")
+	testdb.InsertBlockLog(t, 2, "2020-09-02 00:00:00")
+	testdb.InsertPoolEvents(t, "BNB.TWT-123", "Available")
+	testdb.InsertBlockPoolDepth(t, "BNB.TWT-123", 4, 8, "2020-09-01 00:00:00")
+	testdb.InsertBlockPoolDepth(t, "BNB.TWT-123", 10, 30, "2020-09-02 00:00:00")
+	testdb.InsertStakeEvent(t, testdb.FakeStake{Pool: "BNB.TWT-123", BlockTimestamp: "2020-09-01 00:00:00", StakeUnits: 20})
+	testdb.InsertStakeEvent(t, testdb.FakeStake{Pool: "BNB.TWT-123", BlockTimestamp: "2020-09-02 00:00:00", StakeUnits: 10})
+	testdb.InsertBlockPoolUnits(t, "BNB.TWT-123", 20, "2020-09-01 00:00:00")
+	testdb.InsertBlockPoolUnits(t, "BNB.TWT-123", 30, "2020-09-02 00:00:00")
+
+	var latest, byHeight, byTimestamp oapigen.PoolResponse
+	testdb.MustUnmarshal(t, testdb.CallJSON(t, "http://localhost:8080/v2/pool/BNB.TWT-123"), &latest)
+	testdb.MustUnmarshal(t, testdb.CallJSON(t, "http://localhost:8080/v2/pool/BNB.TWT-123?height=1"), &byHeight)
+	testdb.MustUnmarshal(t, testdb.CallJSON(t, fmt.Sprintf(
+		"http://localhost:8080/v2/pool/BNB.TWT-123?timestamp=%d",
+		testdb.StrToSec("2020-09-01 12:00:00"))), &byTimestamp)
+
+	require.Equal(t, "10", latest.AssetDepth)
+	require.Equal(t, "30", latest.Units)
+	require.Equal(t, "4", byHeight.AssetDepth)
+	require.Equal(t, "8", byHeight.RuneDepth)
+	require.Equal(t, "2", byHeight.AssetPrice)
+	require.Equal(t, "20", byHeight.Units)
+	require.Equal(t, "available", byHeight.Status)
+	require.Equal(t, byHeight, byTimestamp)
+
+	pools := callPools(t, "http://localhost:8080/v2/pools?height=1")
+	require.Equal(t, "20", pools["BNB.TWT-123"].Units)
+
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/pool/BNB.TWT-123?height=3")
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/pool/BNB.TWT-123?height=1&timestamp=1")
+	testdb.JSONFailGeneral(t, fmt.Sprintf("http://localhost:8080/v2/pools?timestamp=%d",
+		testdb.StrToSec("2020-08-01 00:00:00")))
+}
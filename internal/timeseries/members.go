@@ -262,6 +262,57 @@ func GetMemberPools(ctx context.Context, address string) (MemberPools, error) {
 	}
 }
 
+// The liquidity provider of an address in a pool, as in GetMemberPools.
+type MemberID struct {
+	Pool string
+	// The rune address, or the asset address of asym providers without one.
+	Address string
+	// The provider has no rune address.
+	AssetOnly bool
+}
+
+// Condition on stake_events for the deposits of the member, with the address as $1.
+func (id MemberID) AddLiquidityFilter() string {
+	if id.AssetOnly {
+		return "asset_addr = $1 AND rune_addr IS NULL"
+	}
+	return "rune_addr = $1"
+}
+
+// GetMemberIDs returns the liquidity providers of the address in every pool it added to.
+// Withdraws of a provider are from its address.
+func GetMemberIDs(ctx context.Context, address string) ([]MemberID, error) {
+	q := `SELECT DISTINCT pool, '' FROM stake_events WHERE rune_addr = $1 ORDER BY pool`
+	if !addressIsRune(address) {
+		q = `SELECT DISTINCT pool, COALESCE(rune_addr, '') FROM stake_events
+		WHERE asset_addr = $1 ORDER BY pool`
+	}
+
+	rows, err := db.Query(ctx, q, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []MemberID
+	for rows.Next() {
+		var pool, pairRuneAddress string
+		err := rows.Scan(&pool, &pairRuneAddress)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case addressIsRune(address):
+			ret = append(ret, MemberID{Pool: pool, Address: address})
+		case pairRuneAddress == "":
+			ret = append(ret, MemberID{Pool: pool, Address: address, AssetOnly: true})
+		default:
+			ret = append(ret, MemberID{Pool: pool, Address: pairRuneAddress})
+		}
+	}
+	return ret, rows.Err()
+}
+
 const mpAddLiquidityQFields = `
 		COALESCE(SUM(asset_E8), 0),
 		COALESCE(SUM(rune_E8), 0),
@@ -0,0 +1,107 @@
+package timeseries
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+)
+
+// SlashPointsPerNode gets the sum of the slash points added per node within the window.
+// Nodes without slash_points events are absent.
+func SlashPointsPerNode(ctx context.Context, window db.Window) (map[string]int64, error) {
+	const q = `SELECT node_addr, SUM(slash_points)
+	FROM slash_points_events
+	WHERE $1 <= block_timestamp AND block_timestamp < $2
+	GROUP BY node_addr`
+	rows, err := db.Query(ctx, q, window.From.ToNano(), window.Until.ToNano())
+	if err != nil {
+		return nil, fmt.Errorf("slash points per node lookup: %w", err)
+	}
+	defer rows.Close()
+
+	m := make(map[string]int64)
+	for rows.Next() {
+		var node string
+		var points int64
+		if err := rows.Scan(&node, &points); err != nil {
+			return m, fmt.Errorf("slash points per node retrieve: %w", err)
+		}
+		m[node] = points
+	}
+	return m, rows.Err()
+}
+
+// SlashPoints is a slash_points event of a node.
+type SlashPoints struct {
+	Points    int64
+	Reason    string
+	Timestamp db.Nano
+}
+
+// NodeSlashPoints gets the slash_points events of node within the window, oldest first.
+func NodeSlashPoints(ctx context.Context, node string, window db.Window) ([]SlashPoints, error) {
+	const q = `SELECT slash_points, reason, block_timestamp
+	FROM slash_points_events
+	WHERE node_addr = $1 AND $2 <= block_timestamp AND block_timestamp < $3
+	ORDER BY block_timestamp`
+	rows, err := db.Query(ctx, q, node, window.From.ToNano(), window.Until.ToNano())
+	if err != nil {
+		return nil, fmt.Errorf("node slash points lookup: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []SlashPoints
+	for rows.Next() {
+		var e SlashPoints
+		if err := rows.Scan(&e.Points, &e.Reason, &e.Timestamp); err != nil {
+			return ret, fmt.Errorf("node slash points retrieve: %w", err)
+		}
+		ret = append(ret, e)
+	}
+	return ret, rows.Err()
+}
+
+// TSSMetric is the duration of a keygen (ID is the vault public key) or a keysign (ID is the
+// outbound transaction).
+type TSSMetric struct {
+	ID               string
+	MedianDurationMs int64
+	Timestamp        db.Nano
+}
+
+// TSSKeygens gets the keygen metrics within the window, oldest first.
+func TSSKeygens(ctx context.Context, window db.Window) ([]TSSMetric, error) {
+	const q = `SELECT pub_key, median_duration_ms, block_timestamp
+	FROM tss_keygen_events
+	WHERE $1 <= block_timestamp AND block_timestamp < $2
+	ORDER BY block_timestamp`
+	return tssMetrics(ctx, q, window)
+}
+
+// TSSKeysigns gets the keysign metrics within the window, oldest first.
+func TSSKeysigns(ctx context.Context, window db.Window) ([]TSSMetric, error) {
+	const q = `SELECT tx, median_duration_ms, block_timestamp
+	FROM tss_keysign_events
+	WHERE $1 <= block_timestamp AND block_timestamp < $2
+	ORDER BY block_timestamp`
+	return tssMetrics(ctx, q, window)
+}
+
+func tssMetrics(ctx context.Context, q string, window db.Window) ([]TSSMetric, error) {
+	rows, err := db.Query(ctx, q, window.From.ToNano(), window.Until.ToNano())
+	if err != nil {
+		return nil, fmt.Errorf("TSS metrics lookup: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []TSSMetric
+	for rows.Next() {
+		var m TSSMetric
+		if err := rows.Scan(&m.ID, &m.MedianDurationMs, &m.Timestamp); err != nil {
+			return ret, fmt.Errorf("TSS metrics retrieve: %w", err)
+		}
+		ret = append(ret, m)
+	}
+	return ret, rows.Err()
+}
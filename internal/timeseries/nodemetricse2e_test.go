@@ -0,0 +1,69 @@
+package timeseries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/internal/timeseries"
+)
+
+func TestSlashPoints(t *testing.T) {
+	blocks := testdb.InitTestBlocks(t)
+
+	blocks.NewBlock(t, "2020-09-01 00:00:00",
+		testdb.SlashPoints{NodeAddress: "thornode1", Points: 2},
+		testdb.SlashPoints{NodeAddress: "thornode2", Points: 5},
+	)
+	blocks.NewBlock(t, "2020-09-02 00:00:00",
+		testdb.SlashPoints{NodeAddress: "thornode1", Points: 3, Reason: "fail_keysign"},
+	)
+	blocks.NewBlock(t, "2020-09-03 00:00:00",
+		testdb.SlashPoints{NodeAddress: "thornode1", Points: 7},
+	)
+
+	window := db.Window{
+		From:  testdb.StrToSec("2020-09-01 00:00:00"),
+		Until: testdb.StrToSec("2020-09-03 00:00:00"),
+	}
+	perNode, err := timeseries.SlashPointsPerNode(context.Background(), window)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"thornode1": 5, "thornode2": 5}, perNode)
+
+	history, err := timeseries.NodeSlashPoints(context.Background(), "thornode1", window)
+	require.NoError(t, err)
+	require.Equal(t, []timeseries.SlashPoints{
+		{Points: 2, Reason: "fail_to_observe", Timestamp: testdb.StrToNano("2020-09-01 00:00:00")},
+		{Points: 3, Reason: "fail_keysign", Timestamp: testdb.StrToNano("2020-09-02 00:00:00")},
+	}, history)
+}
+
+func TestTSSMetrics(t *testing.T) {
+	blocks := testdb.InitTestBlocks(t)
+
+	blocks.NewBlock(t, "2020-09-01 00:00:00",
+		testdb.TSSKeygen{PubKey: "thorpub1", MedianDurationMs: 12000},
+	)
+	blocks.NewBlock(t, "2020-09-02 00:00:00",
+		testdb.TSSKeysign{TxID: "tx1", MedianDurationMs: 800},
+		testdb.TSSKeysign{TxID: "tx2", MedianDurationMs: 900},
+	)
+
+	window := db.Window{
+		From:  testdb.StrToSec("2020-09-01 00:00:00"),
+		Until: testdb.StrToSec("2020-09-03 00:00:00"),
+	}
+	keygens, err := timeseries.TSSKeygens(context.Background(), window)
+	require.NoError(t, err)
+	require.Equal(t, []timeseries.TSSMetric{
+		{ID: "thorpub1", MedianDurationMs: 12000, Timestamp: testdb.StrToNano("2020-09-01 00:00:00")},
+	}, keygens)
+
+	keysigns, err := timeseries.TSSKeysigns(context.Background(), window)
+	require.NoError(t, err)
+	require.Len(t, keysigns, 2)
+	require.ElementsMatch(t, []string{"tx1", "tx2"}, []string{keysigns[0].ID, keysigns[1].ID})
+}
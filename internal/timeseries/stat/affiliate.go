@@ -0,0 +1,152 @@
+package stat
+
+import (
+	"context"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/fetch/record"
+	"gitlab.com/thorchain/midgard/internal/timeseries"
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
+)
+
+// Affiliates are the addresses in the affiliate field of swap memos. Their fee is paid out in
+// RUNE, with an outbound of the swap transaction to the affiliate address.
+
+// AffiliateSwaps selects the affiliate swaps within $1 <= block_timestamp < $2, one row per
+// swap transaction, with the volume in RUNE and the fee paid out. The RUNE asset is $3 and the
+// outbound timeout $4. Filter is added to the WHERE clause.
+func affiliateSwaps(filter string) string {
+	return `
+	SELECT
+		swap.memo_affiliate_addr AS affiliate,
+		swap.block_timestamp,
+		CASE WHEN swap.from_asset = swap.pool
+			THEN swap.to_E8 + swap.liq_fee_in_rune_E8
+			ELSE swap.from_E8 END AS volume_E8,
+		COALESCE((
+			SELECT SUM(out.asset_E8)
+			FROM outbound_events AS out
+			WHERE out.in_tx = swap.tx AND out.to_addr = swap.memo_affiliate_addr AND out.asset = $3
+				AND swap.block_timestamp <= out.block_timestamp
+				AND out.block_timestamp < swap.block_timestamp + $4), 0) AS fee_E8
+	FROM swap_events AS swap
+	WHERE $1 <= swap.block_timestamp AND swap.block_timestamp < $2
+		AND swap.memo_affiliate_addr IS NOT NULL ` + filter + `
+		-- double swaps count once, by their first half
+		AND NOT (swap.from_asset <> swap.pool AND EXISTS (
+			SELECT 1 FROM swap_events AS first
+			WHERE first.block_height = swap.block_height AND first.tx = swap.tx
+				AND first.from_asset = first.pool))`
+}
+
+func affiliateArgs(window db.Window) []interface{} {
+	return []interface{}{window.From.ToNano(), window.Until.ToNano(),
+		record.RuneAsset(), timeseries.OutboundTimeout.Nanoseconds()}
+}
+
+// AffiliateBucket has the affiliate swaps of a time bucket.
+type AffiliateBucket struct {
+	Window   db.Window
+	Count    int64 // swap transactions
+	VolumeE8 int64 // in RUNE
+	FeesE8   int64 // RUNE paid out to the affiliates
+}
+
+// AffiliateHistory gets the affiliate swaps per bucket, of all affiliates when address is
+// empty.
+func AffiliateHistory(ctx context.Context, buckets db.Buckets, address string) (
+	[]AffiliateBucket, error) {
+	args := affiliateArgs(buckets.Window())
+	var filter string
+	if address != "" {
+		filter = "AND swap.memo_affiliate_addr = $5"
+		args = append(args, address)
+	}
+	q := `
+	SELECT
+		` + db.SelectTruncatedTimestamp("s.block_timestamp", buckets) + ` AS time,
+		COUNT(*),
+		SUM(s.volume_E8),
+		SUM(s.fee_E8)
+	FROM (` + affiliateSwaps(filter) + `) AS s
+	GROUP BY time
+	ORDER BY time ASC`
+
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]AffiliateBucket, buckets.Count())
+	for i := range ret {
+		ret[i].Window = buckets.BucketWindow(i)
+	}
+	i := 0
+	for rows.Next() {
+		var time db.Second
+		var bucket AffiliateBucket
+		err := rows.Scan(&time, &bucket.Count, &bucket.VolumeE8, &bucket.FeesE8)
+		if err != nil {
+			return nil, err
+		}
+		for i < len(ret) && ret[i].Window.From < time {
+			i++
+		}
+		if i == len(ret) || ret[i].Window.From != time {
+			return nil, miderr.InternalErrF("affiliate bucket %d out of range", time)
+		}
+		bucket.Window = ret[i].Window
+		ret[i] = bucket
+	}
+	return ret, rows.Err()
+}
+
+// Affiliate has the affiliate swaps of an address.
+type Affiliate struct {
+	Address  string
+	Count    int64 // swap transactions
+	VolumeE8 int64 // in RUNE
+	FeesE8   int64 // RUNE paid out
+}
+
+// Orders for AffiliateLeaderboard
+const (
+	AffiliatesByVolume = "volume"
+	AffiliatesByFees   = "fees"
+)
+
+// AffiliateLeaderboard gets the affiliates with the most volume or fees within the window.
+func AffiliateLeaderboard(ctx context.Context, window db.Window, orderBy string, limit int) (
+	[]Affiliate, error) {
+	order := "volume_E8"
+	if orderBy == AffiliatesByFees {
+		order = "fee_E8"
+	}
+	q := `
+	SELECT
+		s.affiliate,
+		COUNT(*),
+		SUM(s.volume_E8) AS volume_E8,
+		SUM(s.fee_E8) AS fee_E8
+	FROM (` + affiliateSwaps("") + `) AS s
+	GROUP BY s.affiliate
+	ORDER BY ` + order + ` DESC, s.affiliate
+	LIMIT $5`
+
+	rows, err := db.Query(ctx, q, append(affiliateArgs(window), limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []Affiliate
+	for rows.Next() {
+		var a Affiliate
+		if err := rows.Scan(&a.Address, &a.Count, &a.VolumeE8, &a.FeesE8); err != nil {
+			return nil, err
+		}
+		ret = append(ret, a)
+	}
+	return ret, rows.Err()
+}
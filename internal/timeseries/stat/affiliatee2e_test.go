@@ -0,0 +1,98 @@
+package stat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/openapi/generated/oapigen"
+)
+
+func TestAffiliatesE2E(t *testing.T) {
+	blocks := testdb.InitTestBlocks(t)
+
+	blocks.NewBlock(t, "2020-09-01 00:00:00",
+		testdb.PoolActivate{Pool: "BNB.BNB"},
+		testdb.PoolActivate{Pool: "BTC.BTC"})
+
+	// To rune, affiliate fee paid out.
+	blocks.NewBlock(t, "2020-09-01 12:00:00",
+		testdb.Swap{
+			Pool:         "BNB.BNB",
+			Coin:         "100 BNB.BNB",
+			EmitAsset:    "1000 THOR.RUNE",
+			LiquidityFee: 10,
+			TxID:         "TX1",
+			Memo:         "=:THOR.RUNE:thoraddr1::thoraff1:15",
+		},
+		testdb.Outbound{InTxID: "TX1", Coin: "2 THOR.RUNE", ToAddress: "thoraff1"})
+
+	// Double swap, counted once.
+	blocks.NewBlock(t, "2020-09-03 12:00:00",
+		testdb.Swap{
+			Pool:      "BNB.BNB",
+			Coin:      "500 BNB.BNB",
+			EmitAsset: "2000 THOR.RUNE",
+			TxID:      "TX2",
+			Memo:      "=:BTC.BTC:btcaddr1::thoraff2:10",
+		},
+		testdb.Swap{
+			Pool:      "BTC.BTC",
+			Coin:      "2000 THOR.RUNE",
+			EmitAsset: "5 BTC.BTC",
+			TxID:      "TX2",
+			Memo:      "=:BTC.BTC:btcaddr1::thoraff2:10",
+		},
+		testdb.Outbound{InTxID: "TX2", Coin: "3 THOR.RUNE", ToAddress: "thoraff2"},
+		// Not an affiliate.
+		testdb.Swap{
+			Pool:      "BNB.BNB",
+			Coin:      "300 THOR.RUNE",
+			EmitAsset: "30 BNB.BNB",
+			TxID:      "TX3",
+		})
+
+	body := testdb.CallJSON(t,
+		"http://localhost:8080/v2/history/affiliates?interval=day&from=1598918400&to=1599177600")
+	var history oapigen.AffiliateHistoryResponse
+	testdb.MustUnmarshal(t, body, &history)
+
+	require.Equal(t, oapigen.AffiliateHistoryItem{
+		StartTime: "1598918400",
+		EndTime:   "1599177600",
+		Count:     "2",
+		Volume:    "3010",
+		Fees:      "5",
+	}, history.Meta)
+	require.Len(t, history.Intervals, 3)
+	require.Equal(t, "1010", history.Intervals[0].Volume)
+	require.Equal(t, "0", history.Intervals[1].Count)
+	require.Equal(t, "2000", history.Intervals[2].Volume)
+	require.Equal(t, "3", history.Intervals[2].Fees)
+
+	body = testdb.CallJSON(t,
+		"http://localhost:8080/v2/history/affiliates?from=1598918400&to=1599177600&address=thoraff1")
+	testdb.MustUnmarshal(t, body, &history)
+	require.Equal(t, "1", history.Meta.Count)
+	require.Equal(t, "2", history.Meta.Fees)
+	require.Empty(t, history.Intervals)
+
+	body = testdb.CallJSON(t, "http://localhost:8080/v2/affiliates?from=1598918400&to=1599177600")
+	var affiliates oapigen.AffiliatesResponse
+	testdb.MustUnmarshal(t, body, &affiliates)
+	require.Equal(t, oapigen.AffiliatesResponse{
+		{Address: "thoraff2", Count: "1", Volume: "2000", Fees: "3"},
+		{Address: "thoraff1", Count: "1", Volume: "1010", Fees: "2"},
+	}, affiliates)
+
+	body = testdb.CallJSON(t,
+		"http://localhost:8080/v2/affiliates?from=1598918400&to=1599177600&sort=fees&limit=1")
+	testdb.MustUnmarshal(t, body, &affiliates)
+	require.Equal(t, oapigen.AffiliatesResponse{
+		{Address: "thoraff2", Count: "1", Volume: "2000", Fees: "3"},
+	}, affiliates)
+
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/affiliates?sort=count")
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/affiliates?limit=101")
+}
@@ -0,0 +1,171 @@
+package stat
+
+import (
+	"context"
+	"math"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/timeseries"
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
+)
+
+// PriceCandle is the asset price of a pool in a bucket, over the blocks which changed the
+// depths. Open is the price at the start of the bucket, or at the first block with depths in
+// pools without depths before.
+type PriceCandle struct {
+	Window db.Window
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	// Swaps of the pool in the bucket, the volume in rune.
+	Count  int64
+	Volume int64
+	// USD price of rune at the end of the bucket, for the candles in USD.
+	RunePriceUSD float64
+}
+
+func validPrice(price float64) bool {
+	return 0 < price && !math.IsInf(price, 0)
+}
+
+// Starts the candle at the price before the bucket, NaN when there is none.
+func (c *PriceCandle) open(window db.Window, price float64) {
+	c.Window = window
+	if validPrice(price) {
+		c.Open, c.High, c.Low, c.Close = price, price, price, price
+	} else {
+		c.Open, c.High, c.Low, c.Close = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+	}
+}
+
+func (c *PriceCandle) add(price float64) {
+	if !validPrice(price) {
+		return
+	}
+	if math.IsNaN(c.Open) {
+		c.Open, c.High, c.Low = price, price, price
+	}
+	c.High = math.Max(c.High, price)
+	c.Low = math.Min(c.Low, price)
+	c.Close = price
+}
+
+// Prices the asset of the pool in rune, or in USD with the deepest USD pool.
+func assetPriceFunc(pool string, usd bool) func(depths timeseries.DepthMap) float64 {
+	if !usd {
+		return func(depths timeseries.DepthMap) float64 {
+			return depths[pool].AssetPrice()
+		}
+	}
+	return func(depths timeseries.DepthMap) float64 {
+		return depths[pool].AssetPrice() * runePriceUSDForDepths(depths)
+	}
+}
+
+// PoolPriceCandles returns the price candles of the pool, in rune or in USD.
+// The prices are read from every block of the buckets which changed the depths of the pool (or
+// of the USD pools), so they aren't read from the aggregates. Buckets without a price, like those
+// before the first depths of the pool, have no candle.
+func PoolPriceCandles(ctx context.Context, buckets db.Buckets, pool string, usd bool) (
+	[]PriceCandle, error) {
+	pools := []string{pool}
+	if usd {
+		if len(usdPoolWhitelist) == 0 {
+			return nil, miderr.InternalErr("No USD pools defined")
+		}
+		pools = addUsdPools(pool)
+	}
+	price := assetPriceFunc(pool, usd)
+
+	depths, err := depthBefore(ctx, pools, buckets.Start().ToNano())
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+		SELECT pool, asset_e8, rune_e8, block_timestamp
+		FROM block_pool_depths
+		WHERE pool = ANY($1) AND $2 <= block_timestamp AND block_timestamp < $3
+		ORDER BY block_timestamp
+	`
+	rows, err := db.Query(ctx, q, pools, buckets.Start().ToNano(), buckets.End().ToNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var next struct {
+		pool      string
+		depths    timeseries.DepthPair
+		timestamp db.Nano
+	}
+	hasNext := false
+	readNext := func() error {
+		hasNext = rows.Next()
+		if !hasNext {
+			return rows.Err()
+		}
+		return rows.Scan(&next.pool, &next.depths.AssetDepth, &next.depths.RuneDepth, &next.timestamp)
+	}
+	if err := readNext(); err != nil {
+		return nil, err
+	}
+
+	ret := make([]PriceCandle, buckets.Count())
+	for i := range ret {
+		candle := &ret[i]
+		window := buckets.BucketWindow(i)
+		candle.open(window, price(depths))
+		for hasNext && next.timestamp < window.Until.ToNano() {
+			// The price after all the depth changes of the block.
+			block := next.timestamp
+			for hasNext && next.timestamp == block {
+				depths[next.pool] = next.depths
+				if err := readNext(); err != nil {
+					return nil, err
+				}
+			}
+			candle.add(price(depths))
+		}
+		if usd {
+			candle.RunePriceUSD = runePriceUSDForDepths(depths)
+		}
+	}
+
+	err = addCandleSwaps(ctx, buckets, pool, ret)
+	if err != nil {
+		return nil, err
+	}
+
+	priced := ret[:0]
+	for _, candle := range ret {
+		if !math.IsNaN(candle.Open) {
+			priced = append(priced, candle)
+		}
+	}
+	return priced, nil
+}
+
+func addCandleSwaps(
+	ctx context.Context, buckets db.Buckets, pool string, candles []PriceCandle) error {
+	for _, swapToAsset := range []bool{true, false} {
+		swaps, err := getSwapBuckets(ctx, &pool, buckets, swapToAsset)
+		if err != nil {
+			return err
+		}
+		// Both are ordered by time, swaps is sparse.
+		i := 0
+		for _, swap := range swaps {
+			for i < len(candles) && candles[i].Window.From != swap.Time {
+				i++
+			}
+			if i == len(candles) {
+				return miderr.InternalErr("Swap buckets misalligned")
+			}
+			candles[i].Count += swap.Count
+			candles[i].Volume += swap.VolumeInRune
+		}
+	}
+	return nil
+}
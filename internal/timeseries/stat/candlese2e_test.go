@@ -0,0 +1,90 @@
+package stat_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/internal/timeseries/stat"
+	"gitlab.com/thorchain/midgard/internal/util"
+	"gitlab.com/thorchain/midgard/openapi/generated/oapigen"
+)
+
+func TestCandlesE2E(t *testing.T) {
+	testdb.InitTest(t)
+	testdb.DeclarePools("BNB.BNB", "USDA")
+
+	stat.SetUsdPoolsForTests([]string{"USDA"})
+
+	// assetPrice: 2, runePriceUSD: 2
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 1, 2, "2020-01-05 12:00:00")
+	testdb.InsertBlockPoolDepth(t, "USDA", 200, 100, "2020-01-05 12:00:00")
+
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 1, 5, "2020-01-10 06:00:00")
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 1, 1, "2020-01-10 12:00:00")
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 1, 3, "2020-01-10 18:00:00")
+
+	testdb.InsertSwapEvent(t, testdb.FakeSwap{
+		Pool: "BNB.BNB", FromAsset: "THOR.RUNE", FromE8: 100,
+		BlockTimestamp: "2020-01-10 06:00:00",
+	})
+	testdb.InsertSwapEvent(t, testdb.FakeSwap{
+		Pool: "BNB.BNB", FromAsset: "BNB.BNB", ToE8: 50, LiqFeeInRuneE8: 10,
+		BlockTimestamp: "2020-01-10 12:00:00",
+	})
+
+	// Only the USD price changes, runePriceUSD: 1
+	testdb.InsertBlockPoolDepth(t, "USDA", 100, 100, "2020-01-11 12:00:00")
+
+	from := testdb.StrToSec("2020-01-10 00:00:00")
+	to := testdb.StrToSec("2020-01-12 00:00:00")
+
+	body := testdb.CallJSON(t, fmt.Sprintf(
+		"http://localhost:8080/v2/history/candles/BNB.BNB?interval=day&from=%d&to=%d", from, to))
+
+	var result oapigen.CandleHistoryResponse
+	testdb.MustUnmarshal(t, body, &result)
+
+	require.Len(t, result.Intervals, 2)
+	day1 := result.Intervals[0]
+	require.Equal(t, []string{"2", "5", "1", "3"}, []string{day1.Open, day1.High, day1.Low, day1.Close})
+	require.Equal(t, "2", day1.Count)
+	require.Equal(t, "160", day1.Volume)
+	require.Nil(t, day1.VolumeUSD)
+
+	day2 := result.Intervals[1]
+	require.Equal(t, []string{"3", "3", "3", "3"}, []string{day2.Open, day2.High, day2.Low, day2.Close})
+	require.Equal(t, "0", day2.Count)
+
+	body = testdb.CallJSON(t, fmt.Sprintf(
+		"http://localhost:8080/v2/history/candles/BNB.BNB?currency=usd&interval=day&from=%d&to=%d",
+		from, to))
+	testdb.MustUnmarshal(t, body, &result)
+
+	require.Len(t, result.Intervals, 2)
+	day1 = result.Intervals[0]
+	require.Equal(t, []string{"4", "10", "2", "6"}, []string{day1.Open, day1.High, day1.Low, day1.Close})
+	require.Equal(t, "320", *day1.VolumeUSD)
+
+	day2 = result.Intervals[1]
+	require.Equal(t, []string{"6", "6", "3", "3"}, []string{day2.Open, day2.High, day2.Low, day2.Close})
+
+	// The pool has no depths before 2020-01-05 12:00, so the day before has no candle.
+	from = testdb.StrToSec("2020-01-04 00:00:00")
+	to = testdb.StrToSec("2020-01-06 00:00:00")
+	body = testdb.CallJSON(t, fmt.Sprintf(
+		"http://localhost:8080/v2/history/candles/BNB.BNB?interval=day&from=%d&to=%d", from, to))
+	require.NotContains(t, string(body), "NaN")
+
+	var early oapigen.CandleHistoryResponse
+	testdb.MustUnmarshal(t, body, &early)
+	require.Equal(t, util.IntStr(from.ToI()), early.Meta.StartTime)
+	require.Len(t, early.Intervals, 1)
+	day := early.Intervals[0]
+	require.Equal(t, util.IntStr(testdb.StrToSec("2020-01-05 00:00:00").ToI()), day.StartTime)
+	require.Equal(t, []string{"2", "2", "2", "2"}, []string{day.Open, day.High, day.Low, day.Close})
+
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/history/candles/BNB.BNB?currency=eur")
+	testdb.JSONFailGeneral(t, "http://localhost:8080/v2/history/candles/BTC.BTC")
+}
@@ -81,8 +81,9 @@ func init() {
 			pool,
 			last(asset_e8, block_timestamp) as asset_e8,
 			last(rune_e8, block_timestamp) as rune_e8,
-			%s as bucket_start
+			%[1]s as bucket_start
 		FROM block_pool_depths
+		WHERE %[2]s
 		GROUP BY bucket_start, pool`,
 		`SELECT
 			pool,
@@ -120,15 +121,18 @@ func getDepthsHistory(ctx context.Context, buckets db.Buckets, pools []string,
 		qargs = []interface{}{buckets.Start().ToNano(), buckets.End().ToNano(), pools}
 	}
 
+	// Buckets not aligned with an aggregate are truncated from the table.
+	source, timestamp := buckets.HistorySource("block_pool_depths", "pool_depths")
 	q := `
 		SELECT
 			pool,
-			asset_e8,
-			rune_e8,
-			bucket_start / 1000000000 AS truncated
-		FROM midgard_agg.pool_depths_` + buckets.AggregateName() + `
-		` + db.Where("$1 <= bucket_start", "bucket_start < $2", poolFilter) + `
-		ORDER BY bucket_start ASC
+			last(asset_e8, ` + timestamp + `) AS asset_e8,
+			last(rune_e8, ` + timestamp + `) AS rune_e8,
+			` + db.SelectTruncatedTimestamp(timestamp, buckets) + ` AS truncated
+		FROM ` + source + `
+		` + db.Where("$1 <= "+timestamp, timestamp+" < $2", poolFilter) + `
+		GROUP BY truncated, pool
+		ORDER BY truncated ASC
 	`
 
 	var next struct {
@@ -217,6 +221,11 @@ func USDPriceHistory(ctx context.Context, buckets db.Buckets) (
 	return ret, err
 }
 
+// PoolDepthsAt returns the depths of the pools after the block at moment.
+func PoolDepthsAt(ctx context.Context, pools []string, moment db.Nano) (timeseries.DepthMap, error) {
+	return depthBefore(ctx, pools, moment+1)
+}
+
 func depthBefore(ctx context.Context, pools []string, time db.Nano) (
 	ret timeseries.DepthMap, err error) {
 	poolFilter := ""
@@ -1,6 +1,7 @@
 package stat_test
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"gitlab.com/thorchain/midgard/internal/graphql"
 	"gitlab.com/thorchain/midgard/internal/graphql/generated"
 	"gitlab.com/thorchain/midgard/internal/graphql/model"
+	"gitlab.com/thorchain/midgard/internal/timeseries"
 	"gitlab.com/thorchain/midgard/internal/timeseries/stat"
 	"gitlab.com/thorchain/midgard/internal/util"
 	"gitlab.com/thorchain/midgard/openapi/generated/oapigen"
@@ -204,6 +206,44 @@ func TestLiquidityUnitsHistoryE2E(t *testing.T) {
 	require.Equal(t, "15", jsonResult.Intervals[2].LiquidityUnits)
 }
 
+func TestPoolUnitsTrackingE2E(t *testing.T) {
+	blocks := testdb.InitTestBlocks(t)
+
+	blocks.NewBlock(t, "2020-09-01 00:00:00",
+		testdb.AddLiquidity{Pool: "BTC.BTC", AssetAmount: 1, RuneAmount: 2, Units: 10},
+		testdb.AddLiquidity{Pool: "BNB.BNB", AssetAmount: 1, RuneAmount: 2, Units: 100},
+		testdb.PoolActivate{Pool: "BTC.BTC"},
+		testdb.PoolActivate{Pool: "BNB.BNB"})
+	blocks.NewBlock(t, "2020-09-02 00:00:00",
+		testdb.AddLiquidity{Pool: "BTC.BTC", AssetAmount: 1, RuneAmount: 2, Units: 20},
+		testdb.Withdraw{Pool: "BTC.BTC", EmitAsset: 1, EmitRune: 1, Units: 5})
+	// no unit changes
+	blocks.NewBlock(t, "2020-09-03 00:00:00",
+		testdb.Swap{Pool: "BNB.BNB", Coin: "1 BNB.BNB", EmitAsset: "1 THOR.RUNE"})
+
+	require.Equal(t, map[string]int64{"BTC.BTC": 10 + 20 - 5, "BNB.BNB": 100},
+		timeseries.Latest.GetState().PoolUnits)
+
+	body := testdb.CallJSON(t, "http://localhost:8080/v2/pool/BTC.BTC")
+	var pool oapigen.PoolResponse
+	testdb.MustUnmarshal(t, body, &pool)
+	require.Equal(t, "25", pool.Units)
+
+	// sparse: a row per changed pool per block
+	rows, err := db.Query(context.Background(),
+		"SELECT pool, units FROM block_pool_units ORDER BY block_timestamp, pool")
+	require.NoError(t, err)
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var pool string
+		var units int64
+		require.NoError(t, rows.Scan(&pool, &units))
+		got = append(got, fmt.Sprintf("%s=%d", pool, units))
+	}
+	require.Equal(t, []string{"BNB.BNB=100", "BTC.BTC=10", "BTC.BTC=25"}, got)
+}
+
 func TestDepthAggregateE2E(t *testing.T) {
 	testdb.InitTest(t)
 	testdb.DeclarePools("A.A", "B.B")
@@ -47,22 +47,57 @@ func (peMap poolEarningsMap) getPoolEarnings(pool string) *poolEarnings {
 	}
 }
 
+func init() {
+	db.RegisterAggregate(
+		"bond_rewards",
+		`SELECT
+			SUM(bond_e8) AS bond_e8,
+			%[1]s as bucket_start
+		FROM rewards_events
+		WHERE %[2]s
+		GROUP BY bucket_start`,
+		`SELECT
+			SUM(bond_e8) AS bond_e8,
+		%s as bucket_start
+		FROM midgard_agg.bond_rewards_day d
+		GROUP BY bucket_start`,
+	)
+	db.RegisterAggregate(
+		"pool_rewards",
+		`SELECT
+			pool,
+			SUM(rune_e8) AS rune_e8,
+			%[1]s as bucket_start
+		FROM rewards_event_entries
+		WHERE %[2]s
+		GROUP BY bucket_start, pool`,
+		`SELECT
+			pool,
+			SUM(rune_e8) AS rune_e8,
+		%s as bucket_start
+		FROM midgard_agg.pool_rewards_day d
+		GROUP BY bucket_start, pool`,
+	)
+}
+
 func GetEarningsHistory(ctx context.Context, buckets db.Buckets) (oapigen.EarningsHistoryResponse, error) {
 	window := buckets.Window()
 	timestamps := buckets.Timestamps[:len(buckets.Timestamps)-1]
 
 	// GET DATA
+	// The fees are in the swaps aggregate.
+	source, timestamp := buckets.HistorySource("swap_events", "swaps")
 	liquidityFeesByPoolQ := fmt.Sprintf(`
 		SELECT
 			COALESCE(SUM(CASE WHEN from_asset = pool THEN liq_fee_E8 ELSE 0 END), 0) AS rune_fees_E8,
 			COALESCE(SUM(CASE WHEN from_asset <> pool THEN liq_fee_E8 ELSE 0 END), 0) AS asset_fees_E8,
 			COALESCE(SUM(liq_fee_in_rune_E8), 0),
-			%s AS start_time,
+			%[1]s AS start_time,
 			pool
-		FROM swap_events
-		WHERE block_timestamp >= $1 AND block_timestamp < $2
+		FROM %[2]s
+		WHERE %[3]s >= $1 AND %[3]s < $2
 		GROUP BY start_time, pool
-	`, db.SelectTruncatedTimestamp("block_timestamp", buckets))
+	`, db.SelectTruncatedTimestamp(timestamp, buckets), source, timestamp)
 
 	liquidityFeesByPoolRows, err := db.Query(ctx,
 		liquidityFeesByPoolQ, window.From.ToNano(), window.Until.ToNano())
@@ -71,12 +106,13 @@ func GetEarningsHistory(ctx context.Context, buckets db.Buckets) (oapigen.Earnin
 	}
 	defer liquidityFeesByPoolRows.Close()
 
+	source, timestamp = buckets.HistorySource("rewards_events", "bond_rewards")
 	bondingRewardsQ := fmt.Sprintf(`
-	SELECT SUM(bond_e8), %s AS start_time
-	FROM rewards_events
-	WHERE block_timestamp >= $1 AND block_timestamp < $2
+	SELECT SUM(bond_e8), %[1]s AS start_time
+	FROM %[2]s
+	WHERE %[3]s >= $1 AND %[3]s < $2
 	GROUP BY start_time
-	`, db.SelectTruncatedTimestamp("block_timestamp", buckets))
+	`, db.SelectTruncatedTimestamp(timestamp, buckets), source, timestamp)
 
 	bondingRewardsRows, err := db.Query(ctx,
 		bondingRewardsQ, window.From.ToNano(), window.Until.ToNano())
@@ -84,12 +120,13 @@ func GetEarningsHistory(ctx context.Context, buckets db.Buckets) (oapigen.Earnin
 		return oapigen.EarningsHistoryResponse{}, err
 	}
 
+	source, timestamp = buckets.HistorySource("rewards_event_entries", "pool_rewards")
 	poolRewardsQ := fmt.Sprintf(`
-	SELECT SUM(rune_E8), %s AS start_time, pool
-	FROM rewards_event_entries
-	WHERE block_timestamp >= $1 AND block_timestamp < $2
+	SELECT SUM(rune_E8), %[1]s AS start_time, pool
+	FROM %[2]s
+	WHERE %[3]s >= $1 AND %[3]s < $2
 	GROUP BY start_time, pool
-	`, db.SelectTruncatedTimestamp("block_timestamp", buckets))
+	`, db.SelectTruncatedTimestamp(timestamp, buckets), source, timestamp)
 
 	poolRewardsRows, err := db.Query(ctx,
 		poolRewardsQ, window.From.ToNano(), window.Until.ToNano())
@@ -33,9 +33,83 @@ type liquidityOneTableResult struct {
 	buckets map[db.Second]liquidityBucket
 }
 
+// The liquidity changes of an event table, with the asset amounts in rune.
+type liquidityTable struct {
+	aggregate         string
+	table             string
+	assetColumn       string
+	runeColumn        string
+	impLossProtColumn string
+}
+
+var (
+	depositsTable = liquidityTable{
+		aggregate:   "deposits",
+		table:       "stake_events",
+		assetColumn: "asset_E8",
+		runeColumn:  "rune_E8",
+	}
+	withdrawsTable = liquidityTable{
+		aggregate:         "withdraws",
+		table:             "unstake_events",
+		assetColumn:       "emit_asset_E8",
+		runeColumn:        "emit_rune_E8",
+		impLossProtColumn: "imp_loss_protection_e8",
+	}
+)
+
+// TODO(acsaba): To get the depths for a given timestamp, we join by block_timestamp, assuming
+// there will always be a row in block_pool_depths as depth is being changed by the event
+// itself on that block. This won't be the case if for some reason there are other events
+// and the depth ends up being the same than previous block as new row won't be stored
+// Even though unlikely, we need to guard against this.
+func (t liquidityTable) eventsQuery() string {
+	impLoss := "0"
+	if t.impLossProtColumn != "" {
+		impLoss = "base." + t.impLossProtColumn
+	}
+	return `
+		SELECT
+			base.pool,
+			base.block_timestamp,
+			` + querySelectAssetAmountInRune("base."+t.assetColumn, "bpd") + ` AS asset_in_rune_e8,
+			base.` + t.runeColumn + ` AS rune_e8,
+			` + impLoss + ` AS imp_loss_protection_e8
+		FROM ` + t.table + ` AS base
+		INNER JOIN block_pool_depths bpd
+		ON bpd.block_timestamp = base.block_timestamp AND bpd.pool = base.pool`
+}
+
+func init() {
+	// Joins aren't supported by continuous aggregates.
+	for _, t := range []liquidityTable{depositsTable, withdrawsTable} {
+		db.RegisterMaterializedAggregate(
+			t.aggregate,
+			`SELECT
+				pool,
+				COUNT(*) AS count,
+				SUM(asset_in_rune_e8) AS asset_in_rune_e8,
+				SUM(rune_e8) AS rune_e8,
+				SUM(imp_loss_protection_e8) AS imp_loss_protection_e8,
+				%[1]s as bucket_start
+			FROM (`+t.eventsQuery()+`) AS events
+			WHERE %[2]s
+			GROUP BY bucket_start, pool`,
+			`SELECT
+				pool,
+				SUM(count) AS count,
+				SUM(asset_in_rune_e8) AS asset_in_rune_e8,
+				SUM(rune_e8) AS rune_e8,
+				SUM(imp_loss_protection_e8) AS imp_loss_protection_e8,
+			%s as bucket_start
+			FROM midgard_agg.`+t.aggregate+`_day d
+			GROUP BY bucket_start, pool`,
+		)
+	}
+}
+
 func liquidityChangesFromTable(
-	ctx context.Context, buckets db.Buckets, pool string,
-	table, assetColumn, runeColumn, impLossProtColumn string) (
+	ctx context.Context, buckets db.Buckets, pool string, t liquidityTable) (
 	ret liquidityOneTableResult, err error) {
 	window := buckets.Window()
 
@@ -47,28 +121,23 @@ func liquidityChangesFromTable(
 		queryArguments = append(queryArguments, pool)
 	}
 
-	impLossClause := "0"
-	if impLossProtColumn != "" {
-		impLossClause = "SUM(base." + impLossProtColumn + ")"
-	}
-
 	// GET DATA
-	// TODO(acsaba): To get the depths for a given timestamp, we join by block_timestamp, assuming
-	// there will always be a row in block_pool_depths as depth is being changed by the event
-	// itself on that block. This won't be the case if for some reason there are other events
-	// and the depth ends up being the same than previous block as new row won't be stored
-	// Even though unlikely, we need to guard against this.
+	source := "(" + t.eventsQuery() + ")"
+	timestamp := "block_timestamp"
+	count := "COUNT(*)"
+	if buckets.Aggregated() {
+		source, timestamp = buckets.HistorySource(t.table, t.aggregate)
+		count = "SUM(count)"
+	}
 	query := `
 	SELECT
-		COUNT(*) AS count,
-		SUM(` + querySelectAssetAmountInRune("base."+assetColumn, "bpd") + `) AS asset_sum,
-		SUM(base.` + runeColumn + `) as rune_sum,
-		` + impLossClause + ` AS imp_loss,
-		` + db.SelectTruncatedTimestamp("base.block_timestamp", buckets) + ` AS start_time
-	FROM ` + table + ` AS base
-	INNER JOIN block_pool_depths bpd
-	ON bpd.block_timestamp = base.block_timestamp AND bpd.pool = base.pool
-	WHERE ` + poolFilter + `$1 <= base.block_timestamp AND base.block_timestamp < $2
+		` + count + ` AS count,
+		SUM(base.asset_in_rune_e8) AS asset_sum,
+		SUM(base.rune_e8) as rune_sum,
+		SUM(base.imp_loss_protection_e8) AS imp_loss,
+		` + db.SelectTruncatedTimestamp("base."+timestamp, buckets) + ` AS start_time
+	FROM ` + source + ` AS base
+	WHERE ` + poolFilter + `$1 <= base.` + timestamp + ` AND base.` + timestamp + ` < $2
 	GROUP BY start_time
 	`
 
@@ -107,14 +176,12 @@ func GetLiquidityHistory(ctx context.Context, buckets db.Buckets, pool string) (
 	ret oapigen.LiquidityHistoryResponse, err error) {
 	window := buckets.Window()
 
-	deposits, err := liquidityChangesFromTable(ctx, buckets, pool,
-		"stake_events", "asset_E8", "rune_E8", "")
+	deposits, err := liquidityChangesFromTable(ctx, buckets, pool, depositsTable)
 	if err != nil {
 		return
 	}
 
-	withdraws, err := liquidityChangesFromTable(ctx, buckets, pool,
-		"unstake_events", "emit_asset_E8", "emit_rune_E8", "imp_loss_protection_e8")
+	withdraws, err := liquidityChangesFromTable(ctx, buckets, pool, withdrawsTable)
 	if err != nil {
 		return
 	}
@@ -15,13 +15,11 @@ type CountAndTotal struct {
 	ImpermanentLossProtection int64
 }
 
-func liquidityChange(ctx context.Context,
-	w db.Window, table, assetColumn, runeColumn, impLossProtColumn string) (
+func liquidityChange(ctx context.Context, w db.Window, t liquidityTable) (
 	ret CountAndTotal, err error) {
 	buckets := db.OneIntervalBuckets(w.From, w.Until)
 
-	withdraws, err := liquidityChangesFromTable(ctx, buckets, "*",
-		table, assetColumn, runeColumn, impLossProtColumn)
+	withdraws, err := liquidityChangesFromTable(ctx, buckets, "*", t)
 	if err != nil {
 		return
 	}
@@ -39,10 +37,9 @@ func liquidityChange(ctx context.Context,
 }
 
 func UnstakesLookup(ctx context.Context, w db.Window) (ret CountAndTotal, err error) {
-	return liquidityChange(ctx, w,
-		"unstake_events", "emit_asset_E8", "emit_rune_E8", "imp_loss_protection_e8")
+	return liquidityChange(ctx, w, withdrawsTable)
 }
 
 func StakesLookup(ctx context.Context, w db.Window) (ret CountAndTotal, err error) {
-	return liquidityChange(ctx, w, "stake_events", "asset_E8", "rune_E8", "")
+	return liquidityChange(ctx, w, depositsTable)
 }
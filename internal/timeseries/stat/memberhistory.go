@@ -0,0 +1,227 @@
+package stat
+
+import (
+	"context"
+	"math"
+
+	"gitlab.com/thorchain/midgard/internal/db"
+	"gitlab.com/thorchain/midgard/internal/timeseries"
+	"gitlab.com/thorchain/midgard/internal/util/miderr"
+)
+
+// MemberHistoryBucket is the position of a member in a pool at the end of the bucket.
+// Values are in rune, the position at the pool price at the end of the bucket, deposits and
+// withdraws at the pool price after their block.
+type MemberHistoryBucket struct {
+	Window          db.Window
+	LiquidityUnits  int64
+	PoolUnits       int64
+	AssetRedeemable int64
+	RuneRedeemable  int64
+	AssetPrice      float64
+	// Value of the redeemable asset and rune.
+	Value int64
+	// Value of the deposited asset and rune, had they been held instead. Withdraws reduce them
+	// in proportion to the units withdrawn.
+	HodlValue int64
+	// Total value of the deposits and withdraws so far.
+	AddedValue     int64
+	WithdrawnValue int64
+	// Gains against the average cost of the units, the value added for them.
+	RealizedGain   int64
+	UnrealizedGain int64
+}
+
+func (b MemberHistoryBucket) PoolShare() float64 {
+	if b.PoolUnits == 0 {
+		return 0
+	}
+	return float64(b.LiquidityUnits) / float64(b.PoolUnits)
+}
+
+type MemberPoolHistory struct {
+	Pool string
+	// The identifying address of the member in the pool, see timeseries.MemberID.
+	Address string
+	Buckets []MemberHistoryBucket
+}
+
+// A deposit or withdraw of a member.
+type memberChange struct {
+	timestamp  db.Nano
+	withdraw   bool
+	units      int64
+	assetE8    int64
+	runeE8     int64
+	assetPrice float64
+}
+
+// Changes of the member before until, with the pool depths after their block.
+func memberChanges(ctx context.Context, id timeseries.MemberID, until db.Nano) (
+	[]memberChange, error) {
+	const depthAfter = `
+		LEFT JOIN LATERAL (
+			SELECT asset_e8, rune_e8 FROM block_pool_depths AS d
+			WHERE d.pool = base.pool AND d.block_timestamp <= base.block_timestamp
+			ORDER BY d.block_timestamp DESC LIMIT 1
+		) AS depth ON true`
+
+	q := `
+		SELECT
+			base.block_timestamp AS block_timestamp,
+			base.event_index AS event_index,
+			false,
+			base.stake_units,
+			base.asset_e8,
+			base.rune_e8,
+			COALESCE(depth.asset_e8, 0),
+			COALESCE(depth.rune_e8, 0)
+		FROM stake_events AS base` + depthAfter + `
+		WHERE ` + id.AddLiquidityFilter() + ` AND base.pool = $2 AND base.block_timestamp < $3
+		UNION ALL
+		SELECT
+			base.block_timestamp,
+			base.event_index,
+			true,
+			base.stake_units,
+			base.emit_asset_e8,
+			base.emit_rune_e8,
+			COALESCE(depth.asset_e8, 0),
+			COALESCE(depth.rune_e8, 0)
+		FROM unstake_events AS base` + depthAfter + `
+		WHERE from_addr = $1 AND base.pool = $2 AND base.block_timestamp < $3
+		ORDER BY block_timestamp, event_index
+	`
+
+	rows, err := db.Query(ctx, q, id.Address, id.Pool, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []memberChange
+	for rows.Next() {
+		var change memberChange
+		var eventIndex, assetDepth, runeDepth int64
+		err := rows.Scan(
+			&change.timestamp, &eventIndex, &change.withdraw, &change.units,
+			&change.assetE8, &change.runeE8, &assetDepth, &runeDepth)
+		if err != nil {
+			return nil, err
+		}
+		change.assetPrice = timeseries.AssetPrice(assetDepth, runeDepth)
+		ret = append(ret, change)
+	}
+	return ret, rows.Err()
+}
+
+// The running position of a member, amounts in float to keep the proportional parts.
+type memberPosition struct {
+	units     int64
+	hodlAsset float64
+	hodlRune  float64
+	// Value added for the units held.
+	cost      float64
+	added     float64
+	withdrawn float64
+	realized  float64
+}
+
+func (p *memberPosition) apply(change memberChange) {
+	value := float64(change.runeE8) + float64(change.assetE8)*change.assetPrice
+	if !change.withdraw {
+		p.units += change.units
+		p.hodlAsset += float64(change.assetE8)
+		p.hodlRune += float64(change.runeE8)
+		p.cost += value
+		p.added += value
+		return
+	}
+
+	part := 1.0
+	if change.units < p.units {
+		part = float64(change.units) / float64(p.units)
+	}
+	p.units -= change.units
+	p.hodlAsset -= part * p.hodlAsset
+	p.hodlRune -= part * p.hodlRune
+	p.realized += value - part*p.cost
+	p.cost -= part * p.cost
+	p.withdrawn += value
+}
+
+// Values the position of the changes at the end of each bucket.
+func memberPositionHistory(
+	changes []memberChange, depths []PoolDepthBucket, units []UnitsBucket) []MemberHistoryBucket {
+	ret := make([]MemberHistoryBucket, len(depths))
+	var position memberPosition
+	next := 0
+	for i, depth := range depths {
+		for next < len(changes) && changes[next].timestamp < depth.Window.Until.ToNano() {
+			position.apply(changes[next])
+			next++
+		}
+
+		bucket := &ret[i]
+		bucket.Window = depth.Window
+		bucket.LiquidityUnits = position.units
+		bucket.PoolUnits = units[i].Units
+		bucket.AssetRedeemable = int64(math.Round(
+			bucket.PoolShare() * float64(depth.Depths.AssetDepth)))
+		bucket.RuneRedeemable = int64(math.Round(
+			bucket.PoolShare() * float64(depth.Depths.RuneDepth)))
+		bucket.AssetPrice = depth.Depths.AssetPrice()
+
+		value := float64(bucket.RuneRedeemable) + float64(bucket.AssetRedeemable)*bucket.AssetPrice
+		bucket.Value = int64(math.Round(value))
+		bucket.HodlValue = int64(math.Round(
+			position.hodlRune + position.hodlAsset*bucket.AssetPrice))
+		bucket.AddedValue = int64(math.Round(position.added))
+		bucket.WithdrawnValue = int64(math.Round(position.withdrawn))
+		bucket.RealizedGain = int64(math.Round(position.realized))
+		bucket.UnrealizedGain = int64(math.Round(value - position.cost))
+	}
+	return ret
+}
+
+// MemberHistory returns the position history of the address in each pool it added liquidity to
+// before the end of the buckets, or only in the given pool.
+func MemberHistory(ctx context.Context, buckets db.Buckets, address string, pool *string) (
+	ret []MemberPoolHistory, err error) {
+	ids, err := timeseries.GetMemberIDs(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if pool != nil && id.Pool != *pool {
+			continue
+		}
+		changes, err := memberChanges(ctx, id, buckets.End().ToNano())
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		depths, err := PoolDepthHistory(ctx, buckets, id.Pool)
+		if err != nil {
+			return nil, err
+		}
+		units, err := PoolLiquidityUnitsHistory(ctx, buckets, id.Pool)
+		if err != nil {
+			return nil, err
+		}
+		if len(depths) != len(units) || depths[0].Window != units[0].Window {
+			return nil, miderr.InternalErr("Buckets misalligned")
+		}
+
+		ret = append(ret, MemberPoolHistory{
+			Pool:    id.Pool,
+			Address: id.Address,
+			Buckets: memberPositionHistory(changes, depths, units),
+		})
+	}
+	return ret, nil
+}
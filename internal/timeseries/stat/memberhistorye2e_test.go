@@ -0,0 +1,92 @@
+package stat_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gitlab.com/thorchain/midgard/internal/db/testdb"
+	"gitlab.com/thorchain/midgard/internal/util"
+	"gitlab.com/thorchain/midgard/openapi/generated/oapigen"
+)
+
+func TestMemberHistoryE2E(t *testing.T) {
+	testdb.InitTest(t)
+	testdb.DeclarePools("BNB.BNB")
+
+	testdb.InsertStakeEvent(t, testdb.FakeStake{
+		Pool: "BNB.BNB", AssetE8: 100, RuneE8: 1000, StakeUnits: 100,
+		RuneAddress: "thoraddr1", AssetAddress: "bnbaddr1",
+		BlockTimestamp: "2020-01-10 12:00:00",
+	})
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 100, 1000, "2020-01-10 12:00:00")
+
+	// The price goes from 10 to 40.
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 50, 2000, "2020-01-11 12:00:00")
+
+	testdb.InsertUnstakeEvent(t, testdb.FakeUnstake{
+		Pool: "BNB.BNB", FromAddr: "thoraddr1", StakeUnits: 50,
+		EmitAssetE8: 25, EmitRuneE8: 1000,
+		BlockTimestamp: "2020-01-12 12:00:00",
+	})
+	testdb.InsertBlockPoolDepth(t, "BNB.BNB", 25, 1000, "2020-01-12 12:00:00")
+
+	from := testdb.StrToSec("2020-01-10 00:00:00")
+	to := testdb.StrToSec("2020-01-13 00:00:00")
+
+	for _, address := range []string{"thoraddr1", "bnbaddr1"} {
+		body := testdb.CallJSON(t, fmt.Sprintf(
+			"http://localhost:8080/v2/history/member/%s?interval=day&from=%d&to=%d",
+			address, from, to))
+
+		var result oapigen.MemberHistoryResponse
+		testdb.MustUnmarshal(t, body, &result)
+
+		require.Len(t, result.Pools, 1)
+		require.Equal(t, "BNB.BNB", result.Pools[0].Pool)
+		require.Equal(t, "thoraddr1", result.Pools[0].Address)
+		intervals := result.Pools[0].Intervals
+		require.Len(t, intervals, 3)
+
+		require.Equal(t, oapigen.MemberHistoryItem{
+			StartTime:       util.IntStr(from.ToI()),
+			EndTime:         util.IntStr(from.ToI() + 24*60*60),
+			LiquidityUnits:  "100",
+			PoolUnits:       "100",
+			PoolShare:       "1",
+			AssetRedeemable: "100",
+			RuneRedeemable:  "1000",
+			AssetPrice:      "10",
+			Value:           "2000",
+			HodlValue:       "2000",
+			ValueVsHodl:     "0",
+			AddedValue:      "2000",
+			WithdrawnValue:  "0",
+			RealizedGain:    "0",
+			UnrealizedGain:  "0",
+		}, intervals[0])
+
+		require.Equal(t, "40", intervals[1].AssetPrice)
+		require.Equal(t, "4000", intervals[1].Value)
+		require.Equal(t, "5000", intervals[1].HodlValue)
+		require.Equal(t, "-1000", intervals[1].ValueVsHodl)
+		require.Equal(t, "2000", intervals[1].UnrealizedGain)
+
+		// Half of the units are withdrawn, for half of the value added.
+		require.Equal(t, "50", intervals[2].LiquidityUnits)
+		require.Equal(t, "25", intervals[2].AssetRedeemable)
+		require.Equal(t, "1000", intervals[2].RuneRedeemable)
+		require.Equal(t, "2000", intervals[2].Value)
+		require.Equal(t, "2500", intervals[2].HodlValue)
+		require.Equal(t, "2000", intervals[2].WithdrawnValue)
+		require.Equal(t, "1000", intervals[2].RealizedGain)
+		require.Equal(t, "1000", intervals[2].UnrealizedGain)
+	}
+
+	testdb.JSONFailGeneral(t, fmt.Sprintf(
+		"http://localhost:8080/v2/history/member/thoraddr1?pool=BTC.BTC&interval=day&from=%d&to=%d",
+		from, to))
+	testdb.JSONFailGeneral(t, fmt.Sprintf(
+		"http://localhost:8080/v2/history/member/thoraddr2?interval=day&from=%d&to=%d",
+		from, to))
+}
@@ -114,9 +114,34 @@ func PoolsLiquidityUnitsBefore(ctx context.Context, pools []string, until *db.Na
 	return ret, nil
 }
 
-// PoolUnits gets net liquidity units in pools
-func CurrentPoolsLiquidityUnits(ctx context.Context, pools []string) (map[string]int64, error) {
-	return PoolsLiquidityUnitsBefore(ctx, pools, nil)
+// PoolsUnitsAt returns the units of the pools after the block at moment, from the sparse
+// block_pool_units. Pools without units then are left out.
+func PoolsUnitsAt(ctx context.Context, pools []string, moment db.Nano) (map[string]int64, error) {
+	q := `
+		SELECT p.pool, u.units
+		FROM unnest($1::TEXT[]) AS p(pool)
+		JOIN LATERAL (
+			SELECT units FROM block_pool_units
+			WHERE pool = p.pool AND block_timestamp <= $2
+			ORDER BY block_timestamp DESC LIMIT 1
+		) AS u ON true
+	`
+	rows, err := db.Query(ctx, q, pools, moment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[string]int64, len(pools))
+	for rows.Next() {
+		var pool string
+		var units int64
+		if err := rows.Scan(&pool, &units); err != nil {
+			return nil, err
+		}
+		ret[pool] = units
+	}
+	return ret, rows.Err()
 }
 
 // PoolUnits gets net liquidity units in pools
@@ -129,6 +129,38 @@ func volumeSelector(swapToAsset bool) (volumeSelect, directionFilter string) {
 	return
 }
 
+func init() {
+	// The sums of swap_events, volumeSelector works on both.
+	db.RegisterAggregate(
+		"swaps",
+		`SELECT
+			pool,
+			from_asset,
+			COUNT(*) AS count,
+			SUM(from_e8) AS from_e8,
+			SUM(to_e8) AS to_e8,
+			SUM(liq_fee_e8) AS liq_fee_e8,
+			SUM(liq_fee_in_rune_e8) AS liq_fee_in_rune_e8,
+			SUM(swap_slip_bp) AS swap_slip_bp,
+			%[1]s as bucket_start
+		FROM swap_events
+		WHERE %[2]s
+		GROUP BY bucket_start, pool, from_asset`,
+		`SELECT
+			pool,
+			from_asset,
+			SUM(count) AS count,
+			SUM(from_e8) AS from_e8,
+			SUM(to_e8) AS to_e8,
+			SUM(liq_fee_e8) AS liq_fee_e8,
+			SUM(liq_fee_in_rune_e8) AS liq_fee_in_rune_e8,
+			SUM(swap_slip_bp) AS swap_slip_bp,
+		%s as bucket_start
+		FROM midgard_agg.swaps_day d
+		GROUP BY bucket_start, pool, from_asset`,
+	)
+}
+
 // Returns sparse buckets, when there are no swaps in the bucket, the bucket is missing.
 func getSwapBuckets(ctx context.Context, pool *string, buckets db.Buckets, swapToAsset bool) (
 	[]oneDirectionSwapBucket, error) {
@@ -142,18 +174,24 @@ func getSwapBuckets(ctx context.Context, pool *string, buckets db.Buckets, swapT
 
 	volume, directionFilter := volumeSelector(swapToAsset)
 
+	source, timestamp := buckets.HistorySource("swap_events", "swaps")
+	count := "COUNT(*)"
+	if buckets.Aggregated() {
+		count = "SUM(count)"
+	}
+
 	q := `
 		SELECT
-			` + db.SelectTruncatedTimestamp("swap.block_timestamp", buckets) + ` AS time,
-			COALESCE(COUNT(*), 0) AS count,
+			` + db.SelectTruncatedTimestamp("swap."+timestamp, buckets) + ` AS time,
+			COALESCE(` + count + `, 0) AS count,
 			` + volume + ` AS volume,
 			COALESCE(SUM(liq_fee_in_rune_E8), 0) AS fee,
 			COALESCE(SUM(swap_slip_bp), 0) AS slip
-		FROM swap_events AS swap
+		FROM ` + source + ` AS swap
 		` +
 		db.Where(
 			poolFilter,
-			directionFilter, "block_timestamp >= $1 AND block_timestamp < $2") + `
+			directionFilter, timestamp+" >= $1 AND "+timestamp+" < $2") + `
 		GROUP BY time
 		ORDER BY time ASC`
 
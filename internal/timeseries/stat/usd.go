@@ -1,12 +1,14 @@
 package stat
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
 
+	"gitlab.com/thorchain/midgard/internal/db"
 	"gitlab.com/thorchain/midgard/internal/timeseries"
 )
 
@@ -46,6 +48,15 @@ func RunePriceUSD() float64 {
 	return runePriceUSDForDepths(timeseries.Latest.GetState().Pools)
 }
 
+// RunePriceUSDAt is RunePriceUSD after the block at moment.
+func RunePriceUSDAt(ctx context.Context, moment db.Nano) (float64, error) {
+	depths, err := PoolDepthsAt(ctx, usdPoolWhitelist, moment)
+	if err != nil {
+		return 0, err
+	}
+	return runePriceUSDForDepths(depths), nil
+}
+
 func ServeUSDDebug(resp http.ResponseWriter, req *http.Request) {
 	state := timeseries.Latest.GetState()
 	for _, pool := range usdPoolWhitelist {
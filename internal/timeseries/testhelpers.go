@@ -23,6 +23,7 @@ func copyOfLastTrack() (ret *blockTrack) {
 	hash := []byte("hash0")
 	assetDepth := map[string]int64{}
 	runeDepth := map[string]int64{}
+	units := map[string]int64{}
 	interfacePtr := lastBlockTrack.Load()
 	if interfacePtr != nil {
 		oldTrack := interfacePtr.(*blockTrack)
@@ -31,6 +32,7 @@ func copyOfLastTrack() (ret *blockTrack) {
 		hash = oldTrack.Hash
 		assetDepth = copyMap(oldTrack.aggTrack.AssetE8DepthPerPool)
 		runeDepth = copyMap(oldTrack.aggTrack.RuneE8DepthPerPool)
+		units = copyMap(oldTrack.aggTrack.UnitsPerPool)
 	}
 	return &blockTrack{
 		Height:    height,
@@ -39,6 +41,7 @@ func copyOfLastTrack() (ret *blockTrack) {
 		aggTrack: aggTrack{
 			AssetE8DepthPerPool: assetDepth,
 			RuneE8DepthPerPool:  runeDepth,
+			UnitsPerPool:        units,
 		},
 	}
 }
@@ -73,11 +76,20 @@ func SetDepthsForTest(depths []Depth) {
 	setLastBlock(trackPtr)
 }
 
+// SetPoolUnitsForTest sets the units of the pool, keeping the depths. Call it after
+// SetDepthsForTest.
+func SetPoolUnitsForTest(pool string, units int64) {
+	trackPtr := copyOfLastTrack()
+	trackPtr.aggTrack.UnitsPerPool[pool] = units
+	setLastBlock(trackPtr)
+}
+
 func resetAggTrack() {
 	trackPtr := copyOfLastTrack()
 	trackPtr.aggTrack = aggTrack{
 		AssetE8DepthPerPool: make(map[string]int64),
 		RuneE8DepthPerPool:  make(map[string]int64),
+		UnitsPerPool:        make(map[string]int64),
 	}
 	setLastBlock(trackPtr)
 }
@@ -35,12 +35,13 @@ type blockTrack struct {
 type aggTrack struct {
 	AssetE8DepthPerPool map[string]int64
 	RuneE8DepthPerPool  map[string]int64
+	UnitsPerPool        map[string]int64
 }
 
 // Setup initializes the package. The previous state is restored (if there was any).
 func Setup() (lastBlockHeight int64, lastBlockTimestamp time.Time, lastBlockHash []byte, err error) {
 	const q = "SELECT height, timestamp, hash, agg_state FROM block_log ORDER BY height DESC LIMIT 1"
-	rows, err := db.Query(context.Background(), q)
+	rows, err := db.PrimaryQuery(context.Background(), q)
 	if err != nil {
 		return 0, time.Time{}, nil, fmt.Errorf("last block lookup: %w", err)
 	}
@@ -70,6 +71,9 @@ func Setup() (lastBlockHeight int64, lastBlockTimestamp time.Time, lastBlockHash
 	for pool, E8 := range track.RuneE8DepthPerPool {
 		record.Recorder.SetRuneDepth(pool, E8)
 	}
+	for pool, units := range track.UnitsPerPool {
+		record.Recorder.SetPoolUnits(pool, units)
+	}
 
 	return track.Height, track.Timestamp, track.Hash, rows.Err()
 }
@@ -111,6 +115,7 @@ func CommitBlock(height int64, timestamp time.Time, hash []byte) error {
 		aggTrack: aggTrack{
 			AssetE8DepthPerPool: record.Recorder.AssetE8DepthPerPool(),
 			RuneE8DepthPerPool:  record.Recorder.RuneE8DepthPerPool(),
+			UnitsPerPool:        record.Recorder.UnitsPerPool(),
 		},
 	}
 	copy(track.Hash, hash)
@@ -138,6 +143,10 @@ func CommitBlock(height int64, timestamp time.Time, hash []byte) error {
 	if err != nil {
 		return err
 	}
+	err = unitsRecorder.update(timestamp, track.aggTrack.UnitsPerPool)
+	if err != nil {
+		return err
+	}
 
 	// commit in-memory state
 	setLastBlock(&track)
@@ -150,6 +159,79 @@ func CommitBlock(height int64, timestamp time.Time, hash []byte) error {
 	return nil
 }
 
+// ChainDivergenceError is a block which doesn't continue the chain committed in block_log.
+type ChainDivergenceError struct {
+	Height        int64  // height of the new block
+	ParentHash    []byte // parent hash as reported by the new block
+	CommittedHash []byte // hash of Height-1 in block_log
+}
+
+func (e *ChainDivergenceError) Error() string {
+	return fmt.Sprintf(
+		"chain divergence: block %d's parent hash %X doesn't match block_log hash %X of height %d",
+		e.Height, e.ParentHash, e.CommittedHash, e.Height-1)
+}
+
+// VerifyParent checks that the block is next in line after the last commit, and that its
+// parent hash matches the committed hash. Returns a *ChainDivergenceError on mismatch.
+func VerifyParent(height int64, parentHash []byte) error {
+	track := getLastBlock()
+	if track.Height == 0 {
+		// Empty database.
+		return nil
+	}
+	if height != track.Height+1 {
+		return fmt.Errorf("block height %d doesn't follow the last committed height %d",
+			height, track.Height)
+	}
+	if !bytes.Equal(parentHash, track.Hash) {
+		return &ChainDivergenceError{
+			Height:        height,
+			ParentHash:    parentHash,
+			CommittedHash: track.Hash,
+		}
+	}
+	return nil
+}
+
+// LastConsistentHeight walks back from height and returns the highest block where the hash
+// in block_log matches the one given by chainHashes. Returns 0 if none of them matches.
+func LastConsistentHeight(
+	ctx context.Context, height int64,
+	chainHashes func(ctx context.Context, from, to int64) (map[int64][]byte, error)) (int64, error) {
+	const window = 20
+	for to := height; 0 < to; to -= window {
+		from := to - window + 1
+		if from < 1 {
+			from = 1
+		}
+		hashes, err := chainHashes(ctx, from, to)
+		if err != nil {
+			return 0, err
+		}
+
+		const q = "SELECT height, hash FROM block_log WHERE $1 <= height AND height <= $2 ORDER BY height DESC"
+		rows, err := db.PrimaryQuery(ctx, q, from, to)
+		if err != nil {
+			return 0, fmt.Errorf("block_log lookup: %w", err)
+		}
+		for rows.Next() {
+			var h int64
+			var hash []byte
+			if err := rows.Scan(&h, &hash); err != nil {
+				rows.Close()
+				return 0, err
+			}
+			if bytes.Equal(hash, hashes[h]) {
+				rows.Close()
+				return h, nil
+			}
+		}
+		rows.Close()
+	}
+	return 0, nil
+}
+
 func setLastBlock(track *blockTrack) {
 	lastBlockTrack.Store(track)
 	db.SetLastBlockTimestamp(db.TimeToNano(track.Timestamp))
@@ -76,6 +76,53 @@ type AddLiquidityMetadata struct {
 	LiquidityUnits string `json:"liquidityUnits"`
 }
 
+// AffiliateDetails defines model for AffiliateDetails.
+type AffiliateDetails struct {
+
+	// Affiliate address, as given in the swap memos
+	Address string `json:"address"`
+
+	// Int64, number of swap transactions. Double swaps count once.
+	Count string `json:"count"`
+
+	// Int64(e8), rune paid out to the affiliate
+	Fees string `json:"fees"`
+
+	// Int64(e8), swap volume in rune
+	Volume string `json:"volume"`
+}
+
+// AffiliateHistory defines model for AffiliateHistory.
+type AffiliateHistory struct {
+	Intervals AffiliateHistoryIntervals `json:"intervals"`
+	Meta      AffiliateHistoryItem      `json:"meta"`
+}
+
+// AffiliateHistoryIntervals defines model for AffiliateHistoryIntervals.
+type AffiliateHistoryIntervals []AffiliateHistoryItem
+
+// AffiliateHistoryItem defines model for AffiliateHistoryItem.
+type AffiliateHistoryItem struct {
+
+	// Int64, number of swap transactions with an affiliate. Double swaps count once.
+	Count string `json:"count"`
+
+	// Int64, The end time of bucket in unix timestamp
+	EndTime string `json:"endTime"`
+
+	// Int64(e8), rune paid out to the affiliates
+	Fees string `json:"fees"`
+
+	// Int64, The beginning time of bucket in unix timestamp
+	StartTime string `json:"startTime"`
+
+	// Int64(e8), swap volume in rune
+	Volume string `json:"volume"`
+}
+
+// Affiliates defines model for Affiliates.
+type Affiliates []AffiliateDetails
+
 // BlockRewards defines model for BlockRewards.
 type BlockRewards struct {
 	BlockReward string `json:"blockReward"`
@@ -122,6 +169,46 @@ type BoolConstants struct {
 	StrictBondLiquidityRatio bool `json:"StrictBondLiquidityRatio"`
 }
 
+// CandleHistory defines model for CandleHistory.
+type CandleHistory struct {
+	Intervals CandleHistoryIntervals `json:"intervals"`
+	Meta      DepthHistoryMeta       `json:"meta"`
+}
+
+// CandleHistoryIntervals defines model for CandleHistoryIntervals.
+type CandleHistoryIntervals []CandleHistoryItem
+
+// CandleHistoryItem defines model for CandleHistoryItem.
+type CandleHistoryItem struct {
+
+	// Float, price of asset at the end of the interval
+	Close string `json:"close"`
+
+	// Int64, count of the swaps of the pool in the interval
+	Count string `json:"count"`
+
+	// Int64, The end time of bucket in unix timestamp
+	EndTime string `json:"endTime"`
+
+	// Float, highest price of asset in the interval
+	High string `json:"high"`
+
+	// Float, lowest price of asset in the interval
+	Low string `json:"low"`
+
+	// Float, price of asset at the start of the interval
+	Open string `json:"open"`
+
+	// Int64, The beginning time of bucket in unix timestamp
+	StartTime string `json:"startTime"`
+
+	// Int64(e8), volume of the swaps of the pool in the interval in rune
+	Volume string `json:"volume"`
+
+	// Float, volume of the swaps in USD at the rune price at the end of the interval. Only with currency=usd.
+	VolumeUSD *string `json:"volumeUSD,omitempty"`
+}
+
 // Represents a digital currency amount
 type Coin struct {
 
@@ -261,6 +348,9 @@ type Health struct {
 	// True means healthy. False means Midgard is still catching up to the chain
 	InSync bool `json:"inSync"`
 
+	// Read replicas of the database, when configured
+	ReadReplicas *[]ReplicaHealth `json:"readReplicas,omitempty"`
+
 	// Int64, the current block count
 	ScannerHeight string `json:"scannerHeight"`
 }
@@ -395,6 +485,63 @@ type MemberDetails struct {
 	Pools []MemberPool `json:"pools"`
 }
 
+// MemberHistory defines model for MemberHistory.
+type MemberHistory struct {
+	Meta DepthHistoryMeta `json:"meta"`
+
+	// History of each liquidity provider identified with the given address
+	Pools []MemberPoolHistory `json:"pools"`
+}
+
+// MemberHistoryItem defines model for MemberHistoryItem.
+type MemberHistoryItem struct {
+
+	// Int64(e8), total value in rune of the deposits until the end of the interval
+	AddedValue string `json:"addedValue"`
+
+	// Float, price of asset in rune at the end of the interval
+	AssetPrice string `json:"assetPrice"`
+
+	// Int64(e8), the asset the member could withdraw at the end of the interval
+	AssetRedeemable string `json:"assetRedeemable"`
+
+	// Int64, The end time of bucket in unix timestamp
+	EndTime string `json:"endTime"`
+
+	// Int64(e8), value in rune of the deposited asset and rune, had they been held instead
+	HodlValue string `json:"hodlValue"`
+
+	// Int64, units of the member in the pool at the end of the interval
+	LiquidityUnits string `json:"liquidityUnits"`
+
+	// Float, the share of the member in the pool, liquidityUnits / poolUnits
+	PoolShare string `json:"poolShare"`
+
+	// Int64, units of the pool at the end of the interval
+	PoolUnits string `json:"poolUnits"`
+
+	// Int64(e8), gains in rune of the withdraws against the value added for the units withdrawn
+	RealizedGain string `json:"realizedGain"`
+
+	// Int64(e8), the rune the member could withdraw at the end of the interval
+	RuneRedeemable string `json:"runeRedeemable"`
+
+	// Int64, The beginning time of bucket in unix timestamp
+	StartTime string `json:"startTime"`
+
+	// Int64(e8), gains in rune of the units held against the value added for them
+	UnrealizedGain string `json:"unrealizedGain"`
+
+	// Int64(e8), value of the redeemable asset and rune in rune
+	Value string `json:"value"`
+
+	// Int64(e8), value - hodlValue
+	ValueVsHodl string `json:"valueVsHodl"`
+
+	// Int64(e8), total value in rune of the withdraws until the end of the interval
+	WithdrawnValue string `json:"withdrawnValue"`
+}
+
 // MemberPool defines model for MemberPool.
 type MemberPool struct {
 
@@ -429,15 +576,51 @@ type MemberPool struct {
 	RuneWithdrawn string `json:"runeWithdrawn"`
 }
 
+// MemberPoolHistory defines model for MemberPoolHistory.
+type MemberPoolHistory struct {
+
+	// Address identifying the liquidity provider in the pool, its rune address or for providers without one the asset address.
+	Address   string              `json:"address"`
+	Intervals []MemberHistoryItem `json:"intervals"`
+
+	// Pool rest of the data refers to
+	Pool string `json:"pool"`
+}
+
 // Members defines model for Members.
 type Members []string
 
+// Parsed memo of the inbound transaction, for swaps, donations and withdrawals. Absent when the memo could not be parsed. Fields which are not in the memo are empty or 0.
+type MemoMetadata struct {
+
+	// Memo action, e.g. swap, add, withdraw or donate (aliases such as = and + are resolved)
+	Action string `json:"action"`
+
+	// Destination address of a swap, or the paired address of a liquidity addition
+	Address string `json:"address"`
+
+	// Affiliate address
+	AffiliateAddress string `json:"affiliateAddress"`
+
+	// Int64 (Basis points, 0-10000, where 10000=100%), affiliate fee
+	AffiliateBasisPoints string `json:"affiliateBasisPoints"`
+
+	// Target of the memo, the asset to swap to or to withdraw, or the pool
+	Asset string `json:"asset"`
+
+	// Int64(e8), minimum output of a swap
+	Limit string `json:"limit"`
+}
+
 // Metadata defines model for Metadata.
 type Metadata struct {
 	AddLiquidity *AddLiquidityMetadata `json:"addLiquidity,omitempty"`
-	Refund       *RefundMetadata       `json:"refund,omitempty"`
-	Swap         *SwapMetadata         `json:"swap,omitempty"`
-	Withdraw     *WithdrawMetadata     `json:"withdraw,omitempty"`
+
+	// Parsed memo of the inbound transaction, for swaps, donations and withdrawals. Absent when the memo could not be parsed. Fields which are not in the memo are empty or 0.
+	Memo     *MemoMetadata     `json:"memo,omitempty"`
+	Refund   *RefundMetadata   `json:"refund,omitempty"`
+	Swap     *SwapMetadata     `json:"swap,omitempty"`
+	Withdraw *WithdrawMetadata `json:"withdraw,omitempty"`
 }
 
 // Network defines model for Network.
@@ -790,6 +973,16 @@ type RefundMetadata struct {
 	Reason string `json:"reason"`
 }
 
+// ReplicaHealth defines model for ReplicaHealth.
+type ReplicaHealth struct {
+
+	// True means queries are routed to the replica
+	Healthy bool `json:"healthy"`
+
+	// Int64, blocks behind the primary database, -1 when it can't be reached
+	HeightLag string `json:"heightLag"`
+}
+
 // StatsData defines model for StatsData.
 type StatsData struct {
 
@@ -995,6 +1188,15 @@ type ActionsResponse struct {
 	Count string `json:"count"`
 }
 
+// AffiliateHistoryResponse defines model for AffiliateHistoryResponse.
+type AffiliateHistoryResponse AffiliateHistory
+
+// AffiliatesResponse defines model for AffiliatesResponse.
+type AffiliatesResponse Affiliates
+
+// CandleHistoryResponse defines model for CandleHistoryResponse.
+type CandleHistoryResponse CandleHistory
+
 // ConstantsResponse defines model for ConstantsResponse.
 type ConstantsResponse Constants
 
@@ -1019,6 +1221,9 @@ type LiquidityHistoryResponse LiquidityHistory
 // MemberDetailsResponse defines model for MemberDetailsResponse.
 type MemberDetailsResponse MemberDetails
 
+// MemberHistoryResponse defines model for MemberHistoryResponse.
+type MemberHistoryResponse MemberHistory
+
 // MembersResponse defines model for MembersResponse.
 type MembersResponse Members
 
@@ -1079,11 +1284,80 @@ type GetActionsParams struct {
 	Offset int64 `json:"offset"`
 }
 
+// GetAffiliatesParams defines parameters for GetAffiliates.
+type GetAffiliatesParams struct {
+
+	// Start time of the query as unix timestamp
+	From *int64 `json:"from,omitempty"`
+
+	// End time of the query as unix timestamp. Defaults to now.
+	To *int64 `json:"to,omitempty"`
+
+	// Rank by swap volume or by fees paid out. Defaults to volume.
+	Sort *GetAffiliatesParamsSort `json:"sort,omitempty"`
+
+	// Number of affiliates to return. Should be between [1..100], defaults to 10.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// GetAffiliatesParamsSort defines parameters for GetAffiliates.
+type GetAffiliatesParamsSort string
+
+// GetAffiliateHistoryParams defines parameters for GetAffiliateHistory.
+type GetAffiliateHistoryParams struct {
+
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
+
+	// Number of intervals to return. Should be between [1..400].
+	Count *int `json:"count,omitempty"`
+
+	// End time of the query as unix timestamp. If only count is given, defaults to now.
+	To *int64 `json:"to,omitempty"`
+
+	// Start time of the query as unix timestamp
+	From *int64 `json:"from,omitempty"`
+
+	// Return only the swaps of this affiliate address.
+	Address *string `json:"address,omitempty"`
+}
+
+// GetCandleHistoryParams defines parameters for GetCandleHistory.
+type GetCandleHistoryParams struct {
+
+	// Currency of the prices, rune (default) or usd.
+	Currency *GetCandleHistoryParamsCurrency `json:"currency,omitempty"`
+
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
+
+	// Number of intervals to return. Should be between [1..400].
+	Count *int `json:"count,omitempty"`
+
+	// End time of the query as unix timestamp. If only count is given, defaults to now.
+	To *int64 `json:"to,omitempty"`
+
+	// Start time of the query as unix timestamp
+	From *int64 `json:"from,omitempty"`
+}
+
+// GetCandleHistoryParamsCurrency defines parameters for GetCandleHistory.
+type GetCandleHistoryParamsCurrency string
+
 // GetDepthHistoryParams defines parameters for GetDepthHistory.
 type GetDepthHistoryParams struct {
 
-	// Interval of calculations
-	Interval *GetDepthHistoryParamsInterval `json:"interval,omitempty"`
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
 
 	// Number of intervals to return. Should be between [1..400].
 	Count *int `json:"count,omitempty"`
@@ -1095,14 +1369,14 @@ type GetDepthHistoryParams struct {
 	From *int64 `json:"from,omitempty"`
 }
 
-// GetDepthHistoryParamsInterval defines parameters for GetDepthHistory.
-type GetDepthHistoryParamsInterval string
-
 // GetEarningsHistoryParams defines parameters for GetEarningsHistory.
 type GetEarningsHistoryParams struct {
 
-	// Interval of calculations
-	Interval *GetEarningsHistoryParamsInterval `json:"interval,omitempty"`
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
 
 	// Number of intervals to return. Should be between [1..400].
 	Count *int `json:"count,omitempty"`
@@ -1114,17 +1388,17 @@ type GetEarningsHistoryParams struct {
 	From *int64 `json:"from,omitempty"`
 }
 
-// GetEarningsHistoryParamsInterval defines parameters for GetEarningsHistory.
-type GetEarningsHistoryParamsInterval string
-
 // GetLiquidityHistoryParams defines parameters for GetLiquidityHistory.
 type GetLiquidityHistoryParams struct {
 
 	// Return stats for given pool. Returns sum of all pools if missing
 	Pool *string `json:"pool,omitempty"`
 
-	// Interval of calculations
-	Interval *GetLiquidityHistoryParamsInterval `json:"interval,omitempty"`
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
 
 	// Number of intervals to return. Should be between [1..400]
 	Count *int `json:"count,omitempty"`
@@ -1136,8 +1410,27 @@ type GetLiquidityHistoryParams struct {
 	From *int64 `json:"from,omitempty"`
 }
 
-// GetLiquidityHistoryParamsInterval defines parameters for GetLiquidityHistory.
-type GetLiquidityHistoryParamsInterval string
+// GetMemberHistoryParams defines parameters for GetMemberHistory.
+type GetMemberHistoryParams struct {
+
+	// Return the history of this single pool.
+	Pool *string `json:"pool,omitempty"`
+
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
+
+	// Number of intervals to return. Should be between [1..400].
+	Count *int `json:"count,omitempty"`
+
+	// End time of the query as unix timestamp. If only count is given, defaults to now.
+	To *int64 `json:"to,omitempty"`
+
+	// Start time of the query as unix timestamp
+	From *int64 `json:"from,omitempty"`
+}
 
 // GetSwapHistoryParams defines parameters for GetSwapHistory.
 type GetSwapHistoryParams struct {
@@ -1145,8 +1438,11 @@ type GetSwapHistoryParams struct {
 	// Return history given pool. Returns sum of all pools if missing.
 	Pool *string `json:"pool,omitempty"`
 
-	// Interval of calculations
-	Interval *GetSwapHistoryParamsInterval `json:"interval,omitempty"`
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
 
 	// Number of intervals to return. Should be between [1..400].
 	Count *int `json:"count,omitempty"`
@@ -1158,14 +1454,14 @@ type GetSwapHistoryParams struct {
 	From *int64 `json:"from,omitempty"`
 }
 
-// GetSwapHistoryParamsInterval defines parameters for GetSwapHistory.
-type GetSwapHistoryParamsInterval string
-
 // GetTVLHistoryParams defines parameters for GetTVLHistory.
 type GetTVLHistoryParams struct {
 
-	// Interval of calculations
-	Interval *GetTVLHistoryParamsInterval `json:"interval,omitempty"`
+	// Interval of calculations: 5min, hour, day, week, month, quarter, year, or a multiple of a unit (min, h, hour, d, day, week, month, quarter, year), e.g. 4h.
+	Interval *string `json:"interval,omitempty"`
+
+	// IANA time zone of the intervals, by default UTC. Days, weeks, months, quarters and years start at local midnight, also around DST changes. Requires interval.
+	Tz *string `json:"tz,omitempty"`
 
 	// Number of intervals to return. Should be between [1..400].
 	Count *int `json:"count,omitempty"`
@@ -1177,9 +1473,6 @@ type GetTVLHistoryParams struct {
 	From *int64 `json:"from,omitempty"`
 }
 
-// GetTVLHistoryParamsInterval defines parameters for GetTVLHistory.
-type GetTVLHistoryParamsInterval string
-
 // GetMembersAdressesParams defines parameters for GetMembersAdresses.
 type GetMembersAdressesParams struct {
 
@@ -1187,6 +1480,26 @@ type GetMembersAdressesParams struct {
 	Pool *string `json:"pool,omitempty"`
 }
 
+// GetNetworkDataParams defines parameters for GetNetworkData.
+type GetNetworkDataParams struct {
+
+	// Returns the state after the block at this height instead of the latest. Don't provide together with timestamp.
+	Height *int64 `json:"height,omitempty"`
+
+	// Unix second, returns the state after the last block at or before it instead of the latest. Don't provide together with height.
+	Timestamp *int64 `json:"timestamp,omitempty"`
+}
+
+// GetPoolParams defines parameters for GetPool.
+type GetPoolParams struct {
+
+	// Returns the state after the block at this height instead of the latest. Don't provide together with timestamp.
+	Height *int64 `json:"height,omitempty"`
+
+	// Unix second, returns the state after the last block at or before it instead of the latest. Don't provide together with height.
+	Timestamp *int64 `json:"timestamp,omitempty"`
+}
+
 // GetPoolStatsParams defines parameters for GetPoolStats.
 type GetPoolStatsParams struct {
 
@@ -1203,6 +1516,12 @@ type GetPoolsParams struct {
 
 	// Filter for only pools with this status
 	Status *GetPoolsParamsStatus `json:"status,omitempty"`
+
+	// Returns the state after the block at this height instead of the latest. Don't provide together with timestamp.
+	Height *int64 `json:"height,omitempty"`
+
+	// Unix second, returns the state after the last block at or before it instead of the latest. Don't provide together with height.
+	Timestamp *int64 `json:"timestamp,omitempty"`
 }
 
 // GetPoolsParamsStatus defines parameters for GetPools.
@@ -1211,172 +1530,211 @@ type GetPoolsParamsStatus string
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/XIbN/Lgq6B4d7VyMqFJ6stWVWpPsuxffD9/6CwlV6lVTgFnQBLWDDAaYEgxKb/W",
-	"vcC92BUawHxihiAt5TaJ9o+NzAEajUZ3oxvobvw+CHmSckaYFIOT3wcZESlngsA/TkNJOROfzG/qp5Az",
-	"SZhUf+I0jWmIVZPnnwVn6jcRLkiC1V9pxlOSSaohYQ1J/UklSeCP/5qR2eBk8F+elxg81/3Fcz3y4Esw",
-	"kOuUDE4GOMvwWv075LkePiIizGgK7U4Gb5k8OggQy5MpyRCfoYyIPJYCJViGC8rmSC4ImtMlYWhGY0ky",
-	"MRwU0IXMKJsPvnwJBhm5y2lGosHJv8xYQYH9L0UHPv1MQjn4onrUEflEZJ4xgTBDgLPCxfRHM5650PgS",
-	"DF5xJiRmcjda91GygOxC9mrBM8YjgopWyDIAoHVOUrn4gQrJs/WDY1YF7kIOviPMIpRmNCRoYZsGg9c4",
-	"Y5TNxWOh1oDvwo6YJlW0fiA4losHx0aD7WO2BbRAQmKZazZ7T6M5ziKF1Vs25TmLTqMoI0KQh2ex5gC9",
-	"nPaWRdAanZrWdY57h4Wcxjy8fXAsC8i96BWtGmjRu5xGVK4fi+OaA7iQ/F9ULqIMr3AsQCwiknJBZY0F",
-	"3xOlAM+JxDR++JWuQXehqPUiUoNhquQDxXZiKM34kkYkQxGWGJgUI5GSkM5oiBKAXM7gsXB3Yl0q6jgG",
-	"BZ3YtsHgA5Ernj08Oxq4G7aQNj1NPyAi4MejRxBpgLo9bkqC0nwa0xDdknWB4wXn8Tsyx+HDy00JWnOl",
-	"C+dLiaXWipwRlHIeoxWVC/TTGDGcUDYfWiQfBb1uxD62KBhp0aoha5GDaTwKhgB5G/oVBBOPSDEn/51a",
-	"UYVljGzDYHCR8XtKoscRhyrw3u0DWtS3jv+Zk5w8OEYAtRcVaFFH5XE4SHMPiLrPhjCP+RTH6Oz1xeUK",
-	"p4WOUP94rM21AtvJ3woPsPUDtORxnpAAzQiBPVbENK3ur1c/vXssLEvQznXlEseW61O5EAGS8NOUs0gE",
-	"gKz+YYnjnCBlw5CoRP1LYNCoeHZtN0p7KoUawglnc60sMxJjSSIkM8yEdYiChpsXYUm6fTPMOJI0IULi",
-	"JFUyrLZabWxhiVYLGi7gJ4PECitJmlMhifLHWs5aMFgQOl90O4P680MMRJlrEG3HVglSUolXBhgEfk7v",
-	"VQnJ5fkmRGKQlo2Gjmn3JRjw3EGej7n8g1FXbCvaiMAOgihb8nhJIkRZx8it9WjC146Pa40iJYtEIDqr",
-	"rjgVSE0iJmrCPFNf7fS5kzg4I0hIGscoJSwCkyEYEJYng5N/DUQehkSANOiPlYOCJs5NDK/WKSmPCKpA",
-	"VzgdBAMcRYVrMAgGK+MCDIJBxJmStmCQkVnOFNuKFZXhwjF441hDr4ZpVRAPuFxzTKAFuRCwCu+1j0CC",
-	"wWkFx/cVJq3rhsIR+JFRKTqFFidKESuSlJ5DrnogLASds5JDtZGOQHhFHheCXvYzDtLGc54Gaq45nmmf",
-	"dIWzSLTnNi2/OvlV6eiezymYnh2fG7hWh6oBroFxToGz6D2RGQ0dM8BLkuE5UfvCkqiWHQu0R148C9Cp",
-	"bg17j+XeJUEM7CMH7xvolxKzaLreGrzQ/brhJ/ieJnniif17fE9Znnhjb6D7Yv9eN98CexJRzHyRh8b+",
-	"uENzb9TrwDdjTtk2dFdU34buGro38g3wG7EHc8kT96vC1vLBHCD74l0HvQHrhjZoTiFwCLKDwVwr55Ii",
-	"x0ycwuziM+f6OYXJrax4XB5dt9TVpVJjUvUudp5PyuZW38g9Vlv74GSGY0EK2FPOY4JZi4SdoFxoveIu",
-	"U/ATSTMilEGEMIronKoFDfMsIyxcmx2tZSubn/s4AwtBJDqFhkOnXlUN2iBOoR9l6NUPp28/DC9/fn/2",
-	"8Z1y4BO8eTPUMAOLXxcV/O9ygGYOo61nedVa3YAfsxF6nVHAVpc3RweevYHYte6aKp7dL6Fx9YqlTss6",
-	"LkFtXs2hXISuXZG0qESZJNkSx2Kbm5a3RSfjUmzTWVl3rUkCkKCCzaaZvK3i7cVCtd6SJC52arVp2ziK",
-	"s6FZr9SBn1BYoYUsqZ/B/cYS/iZaX6s/7dQ7RfQio6HD+H8TcywDc72lthU7VpYzMkRvh2QIf1p0npsW",
-	"hUbpGe3Hy/POAWEqrUF/vDxHe1MslBOkpxsRkhIh4Yua+jOnEiIsuqJJt99/ZYilXH814DQPb/WIOaP3",
-	"5YmAC7an01BobgTtvmK5FLm3ZZFPaol2H1JInMmNFJySOWVwgLY9HRsCWw5YLl5QlY4qGWoc3GSwwMd1",
-	"aimQlmA+Jgf9W1DXRZbmHfPu6r0BaWsN3+wPCnYXJd+JiK+ed2LSVvWuZg6Pdv6BR+SV28qaKU2oVt/6",
-	"mqU4V417FOWZDR4BzugT5GnjjGCTxW+umaE9IgmVkkTbDcjhuMmSo3fMywXOgLGLwAVlsCLJd5go8Rpw",
-	"LSRJEGUhTwiaE0Yy3DfBIXorERXwQeRJ/QRoRoi+864R7SF2Izv8lvvRV9IcjuC2onkx8htCfLirTrsA",
-	"hZwtSWYOeD/9+OG1+imOSbgt03Wc5VqClLf7BIcLvR/auKcm7F11woW5FW3qBbVrbWf8wNbda/P0bObD",
-	"a/aQO842nOi1o9dZpqGfKlLcViQuRg/qGrVBbcsXPtuCXcEWeYDepMZIPZzpMO7feQtJQ7WUolCx4/4h",
-	"kHWGd1OB+j6umBBlIHhoD36v4Yq+tTrtWZfMOS7rwHKvBxVCS5dd67EtAXJmH7SKag9O0OmSPENqHHxL",
-	"GJplPEF7jMyx/lDaveKa4cpp/BTHmIVq/aj8h0DCqkNR2xpATwBvuuVJ8dnDLKyaYOdxXW0IpRc8lrYx",
-	"2B6siF3PnBH157MShwCJBV+xblQcFzXW5n7XEOY2VTrnUa5+hW9dgmoCDVumlJJEpSQd11dZTlBCcBGD",
-	"uIadhmmSS46Kru1zOGVNXq5Z6AN1iN7gWNgfTXCjshb0pVxog3zz1N4OhQtMmXNUEWLGSPZD/+UxwIAj",
-	"PGmMjtDtdDfWrDLh+kDFdF2Ub4VQ+lrMzY5dJrOzXdtm1p9rp6iDaZjJ8d3k8Hh+NJLh/TI/iJazOBW/",
-	"zW9Xd/sH0eFydZTOjydH89m+S7r0StRAnl29crWcY3GTmRCCsvHh0eRw5Lz+x7EkjrN1am590WpB5IIo",
-	"9UiFZgi0wAKZfsHGk+JgkObTm1uyriMk5YJnaT4d4yhasZSkd9FLdneXzPH6KPmcj9Z3x5NUfs7D5PYl",
-	"lnglyfJgecCOVreEHK4nR3cvRiQM56P72/1jp8LjuSRZfczR/cvo4OXROTl+8WL/eHaIJ9PTo4NX04PR",
-	"66NJOH755iw8OzqeHR7izVHtRjLs3IJi2QuSulm0dlTa4p1ToWTykv5WX779UTAwJ89qZeTRQYmg2szn",
-	"OurzDEc/4ZhGWPLsU5MFjnaAQaKYsgYqzk5KtsUFyX4muE7zo/3xeP+l39CvFnnGrL+7C+4A4BORpde8",
-	"CxXPiVCrXFDhUt8OFGDGEz8wPJ/G5JLO2Xt8fzqvU3Fy4AXjdUKFoJy9yrNlYzW9+r/BNP5Psp4Tdhlj",
-	"sbjg1HBdAed4MtoGkqDzTlB+ZHmTx/HbJH3HhbjIuCQQsqH5p07lg4PRaOSJXM6i93SeQcDY1zDQWxYS",
-	"puyxNsHHnrj8D0xj5TposjdAbAtBkXsXEO9wePtx9nEqFCUUUS4Iw7Fc77BehSX0joe3P6aOlfJDSQnB",
-	"EtMYT2NyYb3fbef1Ht9frnCqNA0gshMMypRBp0NkzRn1DjAqUvCGZ1WtuSvAB5gYTfLkjLPoLbO2d5V/",
-	"9f+2gQWRuGp6b65qwA52AfLzfB5lWNB4B0X2AbykSoDcG+Kenh80slLL/2odxnUoh+MXB34gKrJ1TmK8",
-	"fhOTezqlMW0I2eEW0EiXZh37AYm/fve3UY0elPYD6Kbzwf7Es7/aPymbV/C5IBnlUWNj9wP2E81kjuP3",
-	"eSwv10wudtm/fp7P1XbzjiZUbr3SDSOyYu05jDe3LdY0tppmk9MKchs1DhulaXJ0mRCdBkH/Bt+1Vbd2",
-	"3tY+2t4We3a5rl3LtQs5NhXHHrFJ5Ts0uEshOxVrj6LsUHz96sepThzqoVPaq1LbI4EueWrIh8sFKlMI",
-	"fd3zokeXX15v0HKqXL7zhzPn/QAW8oZr6kU3jU7jQ08/BqDoMNsbEzte0Z4vvWAo77iN9/jw4MX2asb6",
-	"qq3ZtVGtDuxcvGaG5e4Xrk1QW9+4tgDsfOXajYo3izpxcXCqq53rBOm0dlz5EyTUeJylwimnsDHbfReF",
-	"1+ycMG7PdHNGUC5sS32zg6WFA107LmqqsfWvPMsKFDm3PdhtGsubJHxmwn9YZMJ07PAOGuxtIMKzbsw+",
-	"Vc+SvbGDUb2W64+OGqJJSrIEKz6v7+UXmPbH4qY4K/IIbK6FmqcmikkdWmGBUrwmEZqSEOdC396VY6KY",
-	"C3HN0mLYDhIw0h/+yYis3DCEC8zmRKC9VSUN/LuCI55tdYn7574p3YYXLLVAK22rjGxn9pXKyMLp00a2",
-	"ja8mKmBuKXhttvaVdS9q9A35FYqvwvSeuq9Gc7fy84uL69jROjWnU9m7dhs3czpXaJNOa9G4yU9a2zQE",
-	"32VQ1Es8tPb3juiPd1TIImu0UkihXf1BIBopd2lGSaQzS8vL6/Iewsty0ai6g0GcKW/d87XxCI6YgtMo",
-	"IpGvylBTKDPUQCtO17a4RVe87ml56+a64DdUQbnSvdN1JfetE6KtFMK88S5lG273/bCPsCRvaCZ6aRSg",
-	"H2tKughYmKmeWn+YVL7SmKCsQsKuoZXvtNPIynXYdWDPiGQgXjODUS6wRFMSc6s7e1fSHfShOBVlat81",
-	"eyyEyWRkpkRL8q7N3peLdQzIdkxs4Lt5WOvxrVhYddmGgwHnHRjYHW1RnU1DQFurX6VtUFUXzUm05LIl",
-	"PE2W7tZVddeuTpv3Jie2QLhyaz1l0/Hs8yS++/wiWmaHaZ7MwkV4zGQ8u4smy6Pfovu71Weymh0Ogs0p",
-	"192JvrWU5U113lypw1+KnOYNvT9Bq2o/yJr2qAZR7VPkVG/oZxev7PvFsUa2ro+zBJ5Oq6sv4EZK6449",
-	"scxG5XwoTEOdwaeLkfgEKvfmV1XbmpjjShZxf2pW2bSMVj69+LnT49gbo2/RXpnUjL7RsTfigmTvOZOL",
-	"542Ex2fP0P9G4wn6btyrqzePKSS+Jb2D6nJ0ZXw4mBT94zNyL+FIe0OskWpXqVYxtUe+zi0BJg/Hp8AO",
-	"Ee/UkdqDy0hiKq9Y90k7awUYtEeZGbMz+BAimd/g0NwRujw5m87p0EpF0R6T9Yl0uy0rPKie20iBHexD",
-	"b5au2k9JtDHe76p0g1LooXwPZeXWiim0oH8icGTpE69NZuDR2h6bdquqHLZCe6u6prE4bX3iIG6bNo35",
-	"tHm7mznbHFTTBQ0x/aVbp7pjP8H14DPETGE0nSogBA+prWeCmamvMUQfGam2RDowLJuTqIxZd5UA8fVJ",
-	"upJdFW0diU/R5PBw/LI9KfOhUkytvpc3A9Dm96totp9nZJTOD2fqt/x+f528ZKOjydFxfJsRcXjw2+rz",
-	"4iB8MTp4QX5bfD4cTQ7u1k4DjPGo26CD4lLFUbvb1FjwbDyarEfJfp7K+Wi5zCOyXoxG2WTGfjsere6O",
-	"oxfr4ySfzJ3HQiRMJ4dHt+P24MWn/y+UaYhglUxVrINiXZ3MDOrI93Qe+MbBT+aeLHplL1o23xu5Yy7L",
-	"GkrlbbK9JPe8nDEgXJOt1L9ze9dO5f8wibrDPzYdd/jvko8LerjH4LEZbxckCwmT6s+fKYmjE4QZy7Hy",
-	"LmWeMUSEpAmoUH26Bl7zipBbYSL3AyTxrfoCfKsUJpwDKgzBjcZh2EmYh8izHXacKTtLQV0tCNTHJdZv",
-	"VhACRIbzISru1QNlNMxJ5ISc75qC7ISmC81NDjYTwFR10zcQfIaUj1PAh0WZHCz0Nhdp59fczNTOSIfe",
-	"hR9KzHZNCrYcWKktlXemB1cLPvpqxWpZzbZubNUEfSDVI3BCEDZJQdodAHKr6T5XMxWdKuBSuRcRWHo9",
-	"cN0nzT6DTPM1dO2wje0IkpetPMG+IcQojI1gIfHl+Y6DiA3EqY7hB/Qypqkv6qad6uIJ/ereF7ZZxZ0I",
-	"03Vj4h7CA+afYnNIqwGu7olPvim0ke+0/ThZ4tjwsdKz3mtlBtjMxRa+J0wvJsbbcW+qS/56KaQddJH6",
-	"6iEdiryFaGxN647Tdwtcn7d7wvK4lrRwC5FE3yJB4ri4bWsDdtuYtT0EtkwfLHusJQtwK3FQjX05wHHF",
-	"6TOEoo7flqQG8F57BdZPkD+Z/EtQvLuMsVmWiyE8gXruSArslhuSgu61I1WCWXaiy+YNqTKCD0zFhVf3",
-	"G5ikdXfuDToTG0DnjN7lRN+bbAWasvkOeH+LavfynoM5PZpCkZpXPzwgrXCadpPEeDIWrikd/Rz8jRNN",
-	"p0sN4VVX/SkYoY8yvWPA7tgJ289jcmwvJVH+mUL87/fauemMU9m4rluuYYejVThHadWPcnlbaSWau9z9",
-	"qhZiTTpr21rdoas7Ci013V7BJtc0jN+m6mlu/y1LvK0J24ZOwydoauSmtdX0U1q7RMv+q7qoLf+svT22",
-	"TaaG4mqzTktHNPRRlydcfYrhQYJrm5JWHLbfmIDCk+4Ap6+ImPUathUP9bVhs9uN2jvPbWNivYZ2h4t1",
-	"l/V8pJPRv2edwoqX1DHi0QHaO8OCCpRC6kmARt9BzliAVguSEQT/+H48Gv23Z117WHWUP29o8t/mDPnR",
-	"jpC7rKOHt4tKS2crBVUB3a2J2sdjjyg6jsG6UdqNjjVTpwv0xhpDvdBtLZ4O4F+xUvUoYccADcfxUZeq",
-	"OVYnQrstVNmzE/DOy1R07gT9FYtUC9p2B4XsjLjt676cqruxGykeE7ZnBzDv3f1TKbTvLz5+fPese4ya",
-	"C7g5TUF3Qti+9ggxsJRRqaMz9GVW7a0Vq53BVevYn3a+i3uIFJHNpp47rn/HlA//4ToFZsucD/8R+7l9",
-	"i7wP/yG7htvkZPfeWHZ63rnxtFvaty7olT24sUPV1WDVBnAKlHPLden2uj1b37tqGrKqdGp+718kr6Wt",
-	"+Zy+dUZmMZ0vlKdvjLtGxIwJ0KrWhmnnNmcEmzfdysCaj//Zb0SWLT8RHK190pA0x5rBAo2be1bFu4Rd",
-	"Acc3EBp44wjzGU/2Dw6PXLOcmidLSsx12+MXL7vKEN84S8dBpTQ8Dd114XR1vRtsHz5qDufqNONZSKIb",
-	"yW9ignVgpaOuYOpGZzwaTkbD/dHwwFlP7rPz0IXxiPTP7sC5pK3FAoRdC+HktM3DOkOOTY78DcRm+QdU",
-	"1MPJXC+4Wfm5KRm7/8XMuryVtfRuzNlGZySko9De/fq3ycZIQXe/8WZ58wzcU32IkBu5D2oSZDfGplpo",
-	"O3yLSOcYi8VN2i6lczBx8YlLz+j4+G6tdCMoC12lcVwDLG3ljZuQM3HTXRERT0PnIi1JJmhTaY6G+4fD",
-	"kVeI5U0ZYlqemFd4qRNFo8gCpyZsqK0GaVyr6OSAtkZqCHpNG5XEaKyzUT5NpdiSZ4cgbtgXtgiqqmwm",
-	"Dr7UL866i2awRs06JyPZsOrNLW0WT1+rphDr5xKLMYISMReBGplDbaVfjzj3eMgbmtYshOYbUer3IgWx",
-	"eLRxA/9XgBewXRMqH+HtzcfauswFiEOZgj98yNoWnYO4fb4I03itVduPwqTBOadhXM5cNUJ72Ja8fWbd",
-	"TsrmziBKtOB5JoYPe2zbPHxF5dkrSjGFB0c7JpxwJhePOeX9EYrwWnzF4aqtkFypElBxtrshP3TtieFu",
-	"x60lw2vi+HB7AXXTTXQTeCNitx/4/ijyxrq+pNHwKw6J3bHGjVBiTzJRGS48MqvKs3kGBcP0TZLtri/X",
-	"SRZORohnxavhmTui2fN0uEnAMmhacnNb5jNFn/NN51B6CMn9qbnF0Zu/KvAZeNeqJNtB32bX6B7Bo8RH",
-	"9WinxqGt5yxqJzo1mW9Iaf1Ip/fsp7WDOXV8x7mQ8/CldTqy6Til77Sm+/Sk+c5hy8Y4JzOcx9JGMDQd",
-	"ktcMT2Oy2dxpg3FiU3nOf/cibhUoW9dvq/bduXSbEwFfY72FQdtgbzbpevP5wa6K4KbVXAyLmKZougZN",
-	"M7xmZ9QqnQVe6sIXcOK70knPWCCR4DjWbf74gmF/n3JYj3Sh21p65eeYux2uQzcehgu89vfQWhSVPTcz",
-	"23vniz6+l79Quqb+tAwMUJ9t1WbKTAL17jfCTYOsPSO/8R7jgrh/6cFQe6CV32xtuRYeW2Ornyxfv+79",
-	"8Hdd5Rr6fqtcBIt0loWoJiJ9izbetvvcWVee7VML2aCUL95ehmA15enbesT5XmOgZzsWfes14+rhOP0X",
-	"g434Gr9Luh2vADfWdqtVvmmZA9Xn4rzfCbPFE0zEdf1FrI6ilzuerCn4bq0FuG0V1KJQBWWVFgFsXV7z",
-	"lZpgf6FO89A94rlMc1k8oZaSUJe5s4d+5pBymyO/2qJUaFDDzbXWVz+9+3obuQSytYlc6bqzhewa3tdA",
-	"bo7fto8bLf7QV4n/TganUoA4zqEczaYadEW6e3kqNOUsEmhvyuXCll7CLEKmZs2zLR/bLtF5x8Nbr5J4",
-	"8D4/iqG5PWvTtU/27C5zza4WVCByl+NYoF8rxXNgKNilJI41BeCnXwOlMqlEIU+mlBHRLJcJD8TCi7cw",
-	"fzM1eKt32GUg2anpoXumZrfHKsVNWSM9W0NuXQVXYQHTtDWPKuGvoof+1+wDl+REP79GBZIrxctgL87n",
-	"GZljSYoi0qa0li2otHu51hYVPPbFygMBrscIi49Qa7H98mhXpZxLwqJaMb52CAQ3l/SbSgqB2pX3b8/7",
-	"8aMRWmCxGKJLnpB6VN2eyEO1os7qRgIlOCqO7s05LKz+M5TgtTbaMfqNZFwzyOZqEoBs9Wk5PVcX+VuF",
-	"9Rz1GtZJQqTey+rTPychTXCM9r4bD0foOh+N9sPv4T8EjYejZwGyzwEKtOArNat1AnWzcFyLpccxWmEx",
-	"RCPzyCQwUrxGZXNn7IuyOcp3b77SLimtEZClQi4RXzF9C13WufyK4qSlcm1WKM1IwpfWsymrdEKCcfGs",
-	"a51qD2nkNXioVW2z5IM65esjtnnsCzw2OuM60ItJHOoInAQifgYRWYr/XpS0GvJM3ze2gv/tu6MXuvbU",
-	"6cVbdJeTjBKBrn74+OmVLojFIoTZWm8RAsWUqX1jSTHQ7YzOsv/7f4SEZmlGUpwRgRRuWaKr8eEpz7VK",
-	"tXXKJEdTgjKCIxqvEbaZBmBWmjJYcNo/hO1aYZXiDGJtK6qBLAmT5nFutbh1hJUZpPegBJQ87OnfCT03",
-	"+6SpQiTBt7rm93cRSZV+Y7KgAcFiPSyIFHEiEOMSLXgcoTCjEkSuMtUhuuJ6s8ChrkBc3O8onPQTQ+Q+",
-	"MHcZYsHzOILR1hX0I5qRUMZrUElUwnlze6EqoRcng8lwPBxBSEJKGE7p4GSwPxxBOEqKzdtKz5eT50Y5",
-	"qn86XQAoPWc1KIayvraiNc1QRmJdgK6iZ4fo1NaiUzti+Sr+dI04I4hnKOEZuWaUOZR0US5bEU2ZEhZU",
-	"Jb+ruuYJSbhmCvsDXls5pkwNeM3MiO5tYYg+QWuBcEZQiueUWWzBMuAzdDgaojc0lmqFFPgpQThNY0qi",
-	"aya5WS0LDnZ1pdj1G0rR4GTwH0SeGjIr6mc4IRJuuv/VpPYrniQYCSUygERMhRwiU4ANTk30fssV6UOa",
-	"UqJ1nBJGyp6DVFWIY5bn2hZ8xpV8qF2r5lKFJ0xZqSScgL9RRhiBynMEgX0JWor6vIn5fclPVXxryE7e",
-	"HE0OjvaPz1+Pj18eHR2ene7vTyZnL44Ozs9evtkfjUbjN+f7x2cHr0fnk8np6Ozo9avXR6eHZ6PjF+en",
-	"ZwcdM5D3cHW0BfqnbG0PriDcX9Sy18wC7L364fTth+Hlz+/PdMJB7Y2j4dXH9x/Pvhu/HnfR1QZ4+6P1",
-	"sZQwZXfXuMlcmyoYwlZ55eya7Sk/O6hl8gfF7hegiCuJCEw8T2DurZ81uAhg1Ooid1BazWCrKRmZVPSM",
-	"4d0qN2D7rdxdZZaT6kitl5kSfE+TPBmcHI6CgTnh6IjE6kGKz2Z6mVxYFR+3QasPk18UKJFyZp7QnoxG",
-	"XfZH0e65UT+fzA9ghYg8SbAyNwfmK1K6Hj6pjSHiYeemcLnC8znJnn9MCVN78f5wZI+CQk2TUutHPMwT",
-	"hc7QpRfPeaijvtozapQl6BiyPpJozOzcDI6NHpF4rtTu4LKK7OAXO+dF8Ti8c9qfIOFTOYtIW10IDC1T",
-	"eXhBzEvuyM7GKgO9N7fmbp6i32U9ddeO5dQf0VtlDBYzMzkn4IaK578ri/vLxomCJtNpzsY/1n6sMKYd",
-	"DcnwmqnNFzwmZUCnPNOWnc4irXvOUHq2cmBzzczplGoAzgM8Xi5XHCU8IuLkmn2DlOuE7OEYKrZPRKXJ",
-	"wBVIKTkwzxTBaULM6Y0Yojdwv8AiZYZF4CCtaBxfMwTmpjIGyt2mGAOMBKwADi0Can9y4ICRoGweExhn",
-	"OLziSBCcqUkK5egoybZPgJB7ZQEW50gCpq/AW7AnKOVCUGX1amqeoMOEsgBC9gIUYaWUCbkNEARUBOgu",
-	"x5kkWYDWBGeAKdwNnaB/jYfDg9HolyE6JzM4eCmDV4rTyCE65+wf0j5aguisnCAVCN6ZhICTb8CYfi75",
-	"CeLAIGDhykAfjAkSchbBZC4s+rnAc2JMucpqf6PDt8Y6Iu8E/fpP+/H7CK+VPzs5ghl8Px792myOpmSm",
-	"9jSFRl9H/S/Jvx8fjV68mBwejTQsqD9uYeGZWjw1LR9gqp0Cd3T8YvTCgDvXKMkVIeY1BsVkkhuOi3SQ",
-	"B9yZwRmOIj14S4H6qfqZ8dVQ8eNHFq8rb4YcjEblWoFpqpmdRCeq9a9tpBtouglxzS7KjQs8drNmsFgK",
-	"RIUCek4LwirvPVksUEIkHpozKaiKtCDXDCZfioc90VO0h32xzichZzM6z7VW1Pyi5MxODaRfi42RKuUN",
-	"KhcCZHymRlHrV5v4y5ddHFDviHImaayo7wDxq+V6cwiql7DSg1mrv7Tm3XY/BGLZS4oNxr/WuqA4hblP",
-	"ocIqGBsBSrXxAfuGMTHsox6dBsZmU9wKvuJSHId5jK27Uhp4Ee4y6SqH0eWghCkL5l8DpcMGwUApMQgP",
-	"U/2UGrOBYYNgYBTZIBgoTVY52+jG+ENbocEFMpBwiC61Hz0lhYwWOrE6p/2Re0KhTWRskrDHIHxduSZR",
-	"TG+8QtG4PxiitzPElaxrEVMeMl0SFrSUQhXRkpU7jGo+6LUoN6N/CSzuMYEGWkbbuNFSIrQlYjuZt1Up",
-	"6zCKdN1ApdB0RUIrk00DieBMmXNio21kG5rzJnv9WVyIPpk5T2bOk5nzZOb8Lcyc10YZelo6T/bGk73x",
-	"Z7Y3GuzeYXLYVp22RqsAyEajo/oustJaRXqhUhn6gdHmY7VvzWNYVN9UlQaKtSpU1yIo4clYeTJWnoyV",
-	"J2Plr2ysFDdUu57LaEULJzLIauZKXLiOGaMzK68dVow5tnk6pnGZTX8Wq+nJaPI2mppi12E1lYXtXmmj",
-	"qNN8ggySjSYTxMHDKtrskwAyNwIdHK80ievM5slsejKbnsymJ7PpyWyyZlM1Jd3PYjKKelt7afhkMD2d",
-	"Mz2ZTKRWAaHDWrqELNouC0ku480xPmUEvo7tsTlBkJQTmM3AlSTUcdel3/rWSUE6AQl9bx4Ah+e50bdo",
-	"gr4xv1yoceFu7smGerKgniyoJwvqL2xBVfKVny7IngyXv7DhUnJ6h93iMBKaRoxOy3v+u0ku+eITlg2J",
-	"75AnI7GkQtKwPKhpJB/rnS6DZ0p5qF9qAL2Hjb9i0wL18M7odV2R3TxltkGkbRIPJJjJcOHCZYheU7kA",
-	"QwKCrRXmzEZgm/4mAQlwrLHkYMqm48/3s8Vk/uLwbn85ktHd4dGMkeX90X14L0O2kCIJ86ODxB1HWGbx",
-	"+IcS7sQdVbJ15SToNsi+ml5nCuHPC40I/aJWYO38zqy1MsJOiwZqmwW9IBZ8xRBnIQFdoRaI/EMgnT5u",
-	"KoWYxdGJkfUVu2YwlBkj0DAVLrWeyg5V6wqDdSSRaZqIU4OhpxcO45kJojQjgjDZfLp/J3f7K9a+f9Ub",
-	"mSgmR3S3tAyTe2vT2ls0Nd/P9eft52P6d8zHjg7gi/nYktX9s4HkP6XLPvCI2CTYW7I2XGaYwKmXdFHs",
-	"naajenZNRn2rLw28CPo7sPtm/RxpWa6WVjgpfE5IJgnQ5GBRnNKfXvw8RK7pXWjO7GV+EGvg5I1Jdw0t",
-	"KDblaz2IHKg5dIaqFnTC4CA7yW0eYu1M0So3wDLbWh/BXUFhlaKxXY8ZJXFkipeB8w2bo+17zUKeafQj",
-	"JVflKQOKeWhynX+iguqRFk1HXunAKKNxHPFVZ5Zs8Trov9HqBm12Vt9CKYoSH4qIqpsloTlAAI9VP/EE",
-	"Snh4zUzxT2Vk7o+iZiKurrzq1MUAxWnOj1Uf3fNYtdD1Wl/C/+8fHUJB1Dh22fA78y0sUQfzwoFOyXw9",
-	"rPs8JnMcrrszz+FzgH4aIyHXMYH11vv2FIe3K5xF8KIilnRKYyrXQ3QREywISug8wxJy+atMFaBwQcLb",
-	"a1bh9qiaIKiZVEvfJg7V2P0VtJCeid9yor3lWM2Hsvmz2tLuZpHZHUEbSMpm4vraVXTRfqNm0Hn65nAg",
-	"tkWGTFUBal8S7xCz4mNbzIqSFPoZDf36pcgFFIiIHlS8+kSrsf/27wG9htE85lMcV6JayqsYsC+Ux1Qp",
-	"k+C8ETKqeoeD7R4N8h8aMQ28mKhO/x1+Ng9P9M53kSdY1/xIcLigTBcWgXoiNo3YVMWoZy133HqpDl45",
-	"yjuP2zrTV23RGwrctiFnuajp8jys1q52EshWt7bpurnQhS7KSiMBErw8cTDNQsyUa8KXJMtopLskNKGZ",
-	"U0r1EytlIe1d+KPo3SUKepAK4uV4bcKYQiM3hfPXSaC3piRJ6SaCX2Ze+i1Ncuu1BXCQmJJMnw2aQiTW",
-	"U4e6rzq0F83gYR0m4zWiswreCyxQkseSpjFBGMrBuG16M2WDYeGm7kTeJhBvKlv6lMO3qa3MHniGqE9O",
-	"M0qW2kIiQiJoDmVzbIGgMOPKnY5jU2OojyTvigF3Ckuyvb2JUI7Xnryfb6erHmq40KNiJ+uqVDxTv/dM",
-	"encXrwrAe856uPZ87+yLSd7zhR7bz1c/zbTLfKGn90T1OF++fPny/wIAAP//prw4nTfsAAA=",
+	"H4sIAAAAAAAC/+y9/XIbN7Io/ioo/s6v1k4mFPXpj6rUXsmyN77HHzqWkq3UKkcBZ0AS9hCgBxhJ9Mav",
+	"dV/gvtitbgAzmBnMcEhJOdkN94+NxQEajUZ/odFo/HMQy/lCCia0Gjz/5yBjaiGFYvjHcay5FOqD/Q1+",
+	"iqXQTGj4J10sUh5TaLLzUUkBv6l4xuYU/rXI5IJlmhtI1ECCf3LN5viP/8jYZPB88P/tlBjsmP5qx4w8",
+	"+BoN9HLBBs8HNMvoEv6OZW6GT5iKM77Ads8Hr4U+OoiIyOdjlhE5IRlTeaoVmVMdz7iYEj1jZMqvmSAT",
+	"nmqWqeGggK50xsV08PVrNMjY55xnLBk8/4cdKyqw/6XoIMcfWawHX6FHFZEPTOeZUIQKgjgDLrY/mcgs",
+	"hMbXaHA8mfCUU81+4ErLbLkRyTsJWhsghHrRhqgbuiA4+4hcyzSfM0JFQiaMkZnr7yGtHg5d1YmoIhkV",
+	"n1hCxkuDs0VWZoCrAiRfUJGkD0bWCvQQqmcZjxmJsZnBRwqlqdD3T7MCcgiPi5nMhEwYKVoRJ+rIgKds",
+	"oWcPRSUfeAg5/I4ctkByeTz2kmaCi6l6KNRq8EPYMdvER+sHRlM9u3dsDNgutTLDFkRpqnOjUN7yZEqz",
+	"BLB6LcYyF8lxkmRMqQcQy/oAnZz2WiTYmhzb1lWOe0OVHqcy/nTvWBaQO9ErWtXQ4p9znnC9fCiOqw8Q",
+	"QvLvXM+SjN7QVKFYJGwhFdcVFnzLwNSdMk15ev8rXYEeQtFYQAKDUQ7yQVI3MbLI5DVPWEYSqikyKSVq",
+	"wWI+4TGZI+RyBg9F5wr0oGoGknIpHFHRUjfQeyjSBolaegxpip7C3LWNBu+YvpHZ/UuLhbvCl2kut+2H",
+	"a4z4yeQBNA5CXR83EPBFPk55TD6xZYHjmZTpGzal8f2zWwnaCE0I53NNtVHaUjCykDIlN1zPyE+7RNA5",
+	"F9OhQ/JB0GtH7H2Dggm2rSLrkMNpPAiGCHkd+hUEUw9IsbAP6kQVlzFxDaPBWSZvOUseRhx84J3WDVtU",
+	"Ldt/5Sxn944RQu1EBVtUUXkYDjLcg6Lex15NUzmmKTl5eXYOWwenI+CPh7JJHuwgfze2XRHuucAFUClf",
+	"+Ob/4qc3D4VlCTq4rlLT1HH9Qs9URDT+NJYiUREia364pmnOCLhYLClR/xpZNLwQQ3M/b7bMhRqicymm",
+	"RllmLKWaJURnVCi3M49q8YaEatYeJKBCEs3nTGk6X4AMg6k1viDV5GbG4xn+ZJG4oSBJU640y1jSjBpE",
+	"gxnj01l7VMJ8vo+BuAgNYtxsnyAllaQ3wCDqF325KCGFQjBzpilKy0pHx7b7Gg1kHiDP+1z/zqgD26om",
+	"ImhBCBfXMr1mCeGiZeTGetThm31ZaI0SkEWmCJ/4K84VgUmkDCYsM/jqpi+DxKEZI0rzNCULJhJ0GaIB",
+	"E/kc4lUqj2OmUBrMRy9iVce5juHFcsHKWJUP9IYuBtGAJkmxcxlEgxu7QxlEg0QKkLZokLFJLoBt1Q3X",
+	"8Wzwy6r4mlkN26ogHnK54ZjICHIhYB7vNWNx0eDYw/Gtx6RV3VDsU34UXKtWoaVzUMRAkqIDyaEHoUrx",
+	"qSg51DjpBIUXgo5O0Mt+dv+2MuBYQy04Rxf7cr5JY37U7M6bEyu6EtskApxNMNKyPAbR5mwuVUj79I29",
+	"IhSfbYfkVObj1MBXxsQRKWI2DA2DobvwKI/Y08cRyXJw/yhHISn0hJtdCKSxpp1A/fghFzjGyvVypI6K",
+	"ULEdyE6icwGdjW0sIBeaZdc0VesGdV8XHa2WXhuAZvPGJBFQ5GHVZ1av/Tn0C/kHcWmq2GC7Bg03ZlXj",
+	"ZED03o2zHvMykVzwebvzcTFjhIFW53PUt+M8/sQ0cFwu+G3pljyAYARlWmma6ZUYj9mUC/SaN8H7gaSv",
+	"RL0k+0aSuAGTnpYbvjqDnpig5g3NkoB2Hpdfgx4FeNEdnxcYHGj5XKOPP1QFcAVMiDInUiRvmc54HLIv",
+	"1yyjUwae+zWDlp0Le2xa4+7A+RfXjAjcwQZ4xUI/11Qk4+Xa4JXp1w5/Tm/5PJ/3xP4tveUin/fG3kLv",
+	"i/1b03wN7FnCqeiLPDbujzs27416FfhqzLlYh+5A9XXobqD3Rr4GfiX2uKHtiftFsRvugzlC7ot3FfQK",
+	"rGvaoD6FKCDIAQYLrVxIigIzCQpziM+C6xcUprCykml59tlQV+egxjT0LvYGH6jmEr6xWwqbr8HzCU0V",
+	"K2CPpUwZFQ0StoIKoVU9Gt7cyavAWdvD849eYUu0mXfXgkNfq1nt3uLXNRs1nbpUqoAb8SqVVEf27BhE",
+	"TimmIcCira9l92NujutvbWK3FXR7JOX+wDgYFyvhP6RbOOPTWStV4CNTuk6dHiin8qYVaipvNgMqF0ys",
+	"uYLo5vVZwz+IK+tyUHpyS7u760b78fy0lWShwbggP56fOvKZPQFStl0khuS9SJdm7xPnWcZEvPw+V8nw",
+	"UmzohuNCW940vBRZ+W246EGNI0Ohzg9skTHFhFaEkoRPOZhDh6+N2DRiwfbnriUz/HaMDYO7OmwQiKg4",
+	"tn/xw/Hrd8Pzn9+evH8DB1RzujrYY2BGDr82KqyhZoFmIc3abhzB0l1hnH4l9KqZxVi0vjo66NkbiV3p",
+	"bqjSs/s5Nvb612hZxSWqzKs+VIjQvpm8g632wfwPmeowCn1ZqNK7xVA32jR3iMDZ2KxT6jA4UURZj30T",
+	"gopyPQuOg2K6XV/7YhXvkLwesiH+06GzY1sUGqVjtC7tjFNpDArK+dGYKpYQaaabMLYAewpfYOqPf/fQ",
+	"Us+geOH3Emx3h+UCcq/LIh9giTYf8uH9g16W0ZMOnwwVDq4zWNTnaKChQBqC+ZAc9Iegbogs9RTPzdV7",
+	"DdLaGr7ef+NoeysiffV8EJOmqg81C8QDp5Bt8iLsZU1AEx4dlJG6Upz90AhJ8sxl6SNndAnyuBZhXRUv",
+	"sVme2J6wOdeaJesNKPE41ZGjc8zzGZzRygkp8oYVExiMX3+irNeAS6XZnHARyzkjUyZYRrsmOCSvNeEK",
+	"P6h8Xj3hnDBmUk4rRLsPa+Tvd9awR3ekOR4xr0XzYuRXjPXhrirtIFYgrllmExg+/PjuJfyUpixel+la",
+	"chUcQcrkWkbjmbGH7oJJHfamOuHMZv3V9QJYrfWcHzTdnT5P1+70UtynxVmHE3tZ9CrL1PSTJ8VNRRJi",
+	"9KiqUWvUdnzRxyy4FWyQB+nNKozUwZkB5/5NbyGpqZZSFDw/7i+KuM3wZirQ5JsVE+ICBY88wt8ruJJv",
+	"nU573CZzzZGM5169vYUtAxCyHmYJkbN20CmqR5ghwq/ZYwLj0E9MkEkm5+SRYFNqPpR+r7oU1Ms2GdOU",
+	"ihjWj+u/KKKcOlQV04B6AnkzLE/AZ/ezsDDB1sOOyhCgF3osbW2wR7gibj1zweCfj0scIqJm8ka0oxJI",
+	"RHI+95uaMDep0jqPcvU9vg0Jqr3n03ClQBJBSQbSs7KckTmjxRWgJVoaYUiuJSm6Nk8xogEX50sR94E6",
+	"JK/gKMT+aO8Wgbdgks5id5syX7j0gnhGuQiOmjGafGCYhapCsTyakMx+dhrfzSIiNzMmYIYTPs1NMmQv",
+	"I2bHcxepmqZLxVQIlv3QnbGJE8O4oraeUByOBNQYyVuF6kDFGoTYoXGtqq8bX+/Y5scH23UljhUHY4Nx",
+	"nOndz3uHT6ZHIx3fXucHyfUkXagv0083n/cPksPrm6PF9Mne0XSyHzxYQfaogDy5eBFqOaXqKrN5u2Xj",
+	"w6O9w1Go+YymmgWOS7lNtQQO0jMGOpsrw6VkRhWx/aKVh3/RYJGPrz6xZRUhrWcyW+TjXZokN2LBFp+T",
+	"Z+Lz5/mULo/mH/PR8vOTvYX+mMfzT8+opjeaXR9cH4ijm0+MHS73jj4/HbE4no5uP+0/CWphmWuWVccc",
+	"3T5LDp4dnbInT5/uP5kc0r3x8dHBi/HB6OXRXrz77NVJfHL0ZHJ4SFffabbi6uYWeUlsljRhFq3Ebxu8",
+	"c6xAUZzzL9Xl2x9FAxsOh5XRRwclglxoNjVXrU5o8hNNeUK1zD7UWeBoAxgsSbmooRLsBLKtzlj2M6NV",
+	"mh/t7+7uP+s39ItZngm3Cd8EdwTwgelsGYTSk4qnTMEqF1Q4Z7oCZnevHxjMdjvnU/GW3h5Pq1TcO+gF",
+	"4+WcK8WleJFn17XV7NX/FeXpf7LllInzlKrZmeSW6wo4T/ZG60BSfNoKqh9ZXuVp+nq+eCOVOsukZpgu",
+	"aPinSuWDg9Fo1BO5XCRv+TTDWxp3YaDXImYCnMQmwXd74vK/KU9hP2PIXgOxLgQg9yYg3tD40/vJ+7EC",
+	"SgBRzpigqV5usF6Fe/ZGxp9+XARWqh9KIATXlKd0nLIztyVfd15v6S1c7gFNg4hsBIML8DLNvTQbON8A",
+	"hicFr2Tma81NAd7DxCDPB/JqXgu3IfD51/xvHVh4/Q2m9+qiAuxgEyA/T6dJRhVPN1Bk73Dr5t1KecXC",
+	"0+sHjd3A8r9YxmkVyuHu04N+IDzZOmUpXb5K2S0f85TXhOxwDWisTbPu9gOS3t36u6tEPSjdD2CYzgf7",
+	"ez37g/3kYurhc8YyLpOaYe8H7Cee6Zymb/NUny+Fnm1iv36eTsHcvOFzrtde6ZoT6Xl7Aect7IvVna26",
+	"2xT0gsJOTcBHqbscbS5Eq0PQbeDbTHXD8jbsaNMsdli5NqsVskIBoxKwEatUfkCDhxRyULF2KMoWxdet",
+	"foLqJKAeWqXdl9oOCQzJU00+QlugsqxI3+150aNtX15t0Mx3DOyd350EDy2o0lfSUC+5qnXaPey5j0Eo",
+	"5m7blb2w6WnPZ71gwO64iffu4cHT9dWM26s2ZtdE1R84uHj1qiubnwLXQa19DNwAsPE5cDsqvVk0iEuA",
+	"U0PtQhGk40oM9afV6ZImwIuhV+UuSnadXl6KUyakCzTDsVKuXMsizdHCwa4tp0f+hdYXPW+LFXV4OrBb",
+	"NVZvksiJzUkSiSFPMXyABo9WEOFxO2Yf/AB3b+xw1F7L9XunMvH5gmVzCnxeteVnlHdfr1h4icbugjPM",
+	"0xDF3teHS/oLuoSicyymuUL8vDFJKpW6FIti2BYSCNadkyqY9o494hkVUzj5uPFKQ31XcMTjtU6W/7WP",
+	"b9fhBUct1ErrKiPXWdxRGTk4XdrItemriQqYawpek637ynovanQNeQfF5zF9T91XofnjzdPY2yxaq+YM",
+	"KvuQtQkzZ3CFVum0Bo3r/GS0TU3wQw5Ftexbw763pKS84UoXpVq86mXNinCK8IQJzSecJbhG3ol6eQ7R",
+	"y3MxqIYzVIJ1Jtrn2+oTbpa23Zq680NZaA7P4pv0eQjy/FAWDeqkkvU4exKr1QHEvXreR85NfSCbhV2c",
+	"ADsXKxeap79LAvgmieYfWMLYHHbjq/OXcSyvUEgs8zQpNdR6oz/oRTKZpKsXr2vZmNPYoLvhe0RmNIEW",
+	"SzJmTJAZS0FxK81ococ8dFOIRU58sm6eGA5dMIWw0xkqsmrCY0akijvZwZ/x322DrjHJDaaVMZryLyz5",
+	"G+Wic0WnlAtVX1HHn4pQ/G6GNouPYl4kRBk8XfvW1KI1RAbxuC+J+T38zFzcjdiGhCgcK6g9Dw1/3VNo",
+	"7WhZsRI1ae28iwgQflI/yCTtMdJ3pFQmHT6huJOtKFl0TWOxXlanE2FfnEuV0TQIDXavXe24tlTxKeRT",
+	"N/LNaINWNblu8F675Xa5oIF8zmMYsO/OyDKkzf9CxTReWlFtNZjHbVWpCpDwmeTKVHMvhb8V4t8LhdMX",
+	"73ILg5mV/bBPqGaveKY6aRSRHys6otCNE+iJvztdVlpKLjwStg39hm44MkRINx24pxVG4tWro+kZ1WTM",
+	"Uum2iJ0rGU64BU4lGVPazwskGZvADkLLNgvTl4tN/u16TGzhh3nYeJFrsTB0WYeDEecNGDic6erPpiag",
+	"AcVX0jby1UV9Eg25bAhPnaW7dVXrtqy9wp1dAruLWrqwQGij5XtuwLeVNZQZylG5bQXKy1xj4eHSqbfN",
+	"WwIffO1ofHOH1VLD8h5EpiUFutxldp48GEyrM6si9NZWRSwAeimUYzHenXzcSz9/fJpcZ4eLfD6JZ/ET",
+	"odPJ52Tv+uhLcvv55iO7mRwOotVFN9+yufTLPdYIQzMQyDmby9IxaFTWjHC9sX5CRLCipSm3KZJK8Ikc",
+	"jzFhH7OTrYBL654KCXoPgsiKJUPyirM0UTZoDDsH+M69XvAbmy/0ErhtZHgo9GpOkLSSOLzZcDpExCOg",
+	"dVR6yTIzE2HkEU05VUwRlQMuinyPE/sWUciYwqqnj1uOB8KSdsqU5oZKpdBA/XyDibVBCwrsVW1QyiJN",
+	"Eo7gQgO7QmvHvatZdoI5oYqrMkMloGzJI2xDFtgoIqPvMGEEM9EzRvCP73dHo/8f6kUUY08YW6NexAXN",
+	"pkx7e0hZCRFIW45QIv1ksZQlPVvt9ZwHhvOMiC2wRGSuF7kul2p1lQpXD7YoV+HZCRg1sFYtdA/rkbYy",
+	"rZWCs6vK8oUKv+Jp7Fz20LnS72Or2K68ZwCt/H5IzR71v/0+boFX9XMmtez7NUBL95JD8PUtU6arqrBX",
+	"albTseN2r3UE3xXnEqYimCk/3+fqbmfFEb+tvYXrVSXs7Oo1Le/vHp/93BrhebRLviWPyiKJ5Btz8QPy",
+	"Yt5KoWc7tQJqjx+T/ya7e+S73U4PevWYCi57dQ5q3kcqb0xjiLZ7fMFuNeZTrbjoAu28+uRjl28UdNRx",
+	"8qjzkR0S2eq52jAOm9ta+y6mYqJYBRjyiAs75uPOwNwrGtsE1VB4x5WHC1kJ90yDrSJHTLs1a3pDz3Wk",
+	"wA32rrPqH7hsLFl5A+6iPINbYA/wIuCIpVI+uwH9A8N8mR6gUzZBz8T1WGUSfDlsXHb1dU1tcZr6JEDc",
+	"Jm1q82nydjtzNjmoogtqYvpLu04N34bEcy85IcI0spfnlZIxdxXsqbCOGlTYYn5LYm4lZVMb28MToVDR",
+	"90F0t/JPQNumTWDJ3uHh7rPmpOwH7/mcqu9ev/00vb1JJvt5xkaL6eEEfstv95fzZ2J0tHf0JP2UMXV4",
+	"8OXm4+wgfjo6eMq+zD4ejvYOPi+D22Ihk3aXDz6SIs8rvLWYyWx3tLcczffzhZ6Orq/zhC1no1G2NxFf",
+	"noxuPj9Jni6fzPO9aWh4xeLF3uHRp93m4MWn/xHK1ETQJ5OPdVSsa5CZUR313YxC6xA/2STN5IXL8lud",
+	"tBi+8Fe+mlGmMrsM7Z6ZgRZEaLLei0fhmGdQ+d9P6arh71ugavhHqVCFerjD4XE1YM5YFjOh4Z8/wzb5",
+	"OaFC5BQCGDrPBGFK8zmqUJPagbHMG8Y+KXuXPYIb8vAF+RYUJoYrAEMMbtI4biXMfVSeGrYcNAUf/7gw",
+	"RSw188/y7Ma9SOqOwGmYsmQYPl7asCjXsL2q5N7BagLYs5eixmRRX7IIMO8dzIyZS0xI0qYFVhJ0hr1L",
+	"IZaYbVomy3Gg95pI3lowy3/iq69WLPuEdGPjFbh7Uj2KzhkEbso2htww3R2YqWpVAeewvUjQ0+uAG05z",
+	"6jPIOF9i1xbf2I2gZdmqJ9hXjFmFsRIsloLY2XAQtYI4/hj9gJ6nfNEXddsOuvSEfnHbF7ZdxY0I05au",
+	"Fx6iB8x/CeOw8G9Xhie+902hjfpOux8na5paPgY923ut7ACrudjB7wmzFxPT9bh3YR557KWQNtBF8LWH",
+	"dAB5C9FYm9YtZ6IOuDkF7QmrR06sg1uIJPmWKJa6riHAYR+zYkPMu+Q9sOzwlhzAtcQBGvflgEB+bZ8h",
+	"gDr9TBIM0HvtAWw/Qf5gKxKRHfvHumOsluViiJ5Ae1okALumQQLoF7c9YXsGaW26rDZI3gh9YAIXXtyu",
+	"YJJG4nZv0JlaAToX/HPOzDnpWqDhVuP6eH9LKknhPQcL7mjcIO4Z+h6Qbuhi0U4Su5NxcO1joTvQSz03",
+	"dDo3EF60VWTGEboo0zkGWsdW2P12TAHzUhLlrwu8fPq92dy0JsStXNc117Blo1Vsjhb+Piq021p4V4n9",
+	"BLjSQ6xIZ8WsVTd01Y1CQ003V7DONTXnt6566ua/4Yk3NWHT0antCeoaue5t1fcpDSvR8P/8LWpjf9Y0",
+	"j02Xqaa4mqzT0BE1fdS2E/Yf376Xm511SSuC7Vf2Ntvz9ts1d7iu2WvYxmWcu97ZXG/UznmueyGz19Dh",
+	"u0rtiQsPFBn9c1bu93ZJLSOul38StmH+KP+692L/NDHkBwsht3lH9+8XlZ7OWgrKA92uiZrhsQcUncBg",
+	"7ShtRseKq9MGemXV3U7orjptC/A7rFT1impggNrG8UGXqj5WK0KbLVTZsxXwxstUdG4FfYdF8rq3JYVs",
+	"jLjrGz6cqm5jV1I8ZeKRG8Dkrau/gkL7/uz9+zeP28eobAFX35E3nQi1RW/NzQQuuDbZGTi16tvPTjvj",
+	"Vq3FPm18Fncf9QlWu3rhS+Ub1hvoP1yrwKxZcKD/iN3cvkbRgf5Dtg23apPdeWLZuvPO3c3RuvatCrpn",
+	"g2sWqqoGfR8gKFBBkxvS7VV/tmq7KhrSVzqVfe+/SVGFpuYL7q0zNkkhTeW8cO7qb9UnrF6YtFlYK2NU",
+	"yVpizfv/7HYiy5ZQbX3Z5yKk4Vg7WGRwC89K3nKWhJPLTJ7fFaYGXgXSfHb39g8Oj0KzHNsnkEvMTdsn",
+	"T5+1PcxzFaxbjmW66TgOFyU3pd2vqHtIvT5cqNNEZjFLrrS8Shk1iZWBSvuLMDq7o+HeaLg/Gh4Ei5l/",
+	"DAZdhExY9+wOgkvaWCxEOLQQQU5bPWww5dgWaLvC3Kz+CRXVdLLQfScnP1clY3dmaNTkrSzkfmVjG62Z",
+	"kIEq77fLL3srMwXD/XZXy1vPxD3ow5ReyX1YEC+7sj7VjC8qq7A60xnqLl4tmnVcD/ZCfBLSMyY/vl0r",
+	"XSku4lBd1tAA167s41UshbpqL8dPx3Fwka5ZpnhdaY6G+4fDUa8Uy6syxbSMmHu81IqiVWRRUBPW1FaN",
+	"NKFVDHJAUyPVBL2ijUpi1NbZKp+6UmzIc0AQV9iFNZKqyk4hvvyvnOWspWKjqBVMDzKSS6te3dLd4ulq",
+	"VRfiG7xGVYwRlYiFCFS7OdRU+tWM884MXa9pxUNovLSipCguhtsLTiv53wNewA5PyH9ypTEf+7BM5+Mz",
+	"n3OWcabMfUSZ6/Jitn0gJvi+jGHzN3Taun9BsVNkzGZcJC6mO6fZ0nto5rtdc5uTaxJT8Re8w5lBMj5b",
+	"TSM3Nx+XEInM0crKa25rl6FEjVGWLhneZ+3J1kHC2+KE8nRptP+Pyt4MbqtqA7vyHBqRR9Q9SfPY7cwh",
+	"IhvKMyUzmWdqeL+R7Xp8mpThabKgPCEy1y0TnkuhZw855f0RSehS3SH+7F4w8qr4efGIdsj3XRtyuFlE",
+	"umR4Q5w+3F5AXXVYXwdeS2ruBr4/SnpjXV3SZHiHOHo4HbuWbd2TTFyDhlt5+aw8vhBY0NsctrnuJv+A",
+	"ZfHeiMiMnLw8g4iCOZvbOIBeJ2CZV66lPVDsM8U+IeDgUMfuMnZvaq4RneyvCvoMvGnV0PWgr2M12kfo",
+	"UYLTj35VOLTxBmYl6FWR+ZqUVqNeneGxhgUL6viW0FkwPtUIIK2KOHUFtNoDTOdIzY7HuU7ZhOapdkke",
+	"9T3bSwGnh6u9nSaYIDY3dHH3IuselLXrq/t9Ny6tHkSg736mgUFzT1NvEnhW+35P0/Aw2sAkCnI7x0vU",
+	"NMNLccKd0pnRa1PfBoPiN+ZeOFVEzeHCMbb5/Qt6/3nKVT/QmXdj6YtaN3h3C4a8Hy7oZd9j51F4Njez",
+	"5r31GeC+5+OAde09WhygOlvfZ2qrd9j/0LzukDVn1G+8hzhD7156dNTuaeVXe1uhhS8q33ST5e7r3g1/",
+	"01WuoN9vlYt8mjYyVe5qfUtWJiT0Odb33vqHhaxRqi/evRxB/1bYt9Wk/Ee1gR5vWJmz042rZix1n53W",
+	"UpD6nWNueEq6svZ6pThQwx3w35jv/bi4qy9hk9Krz2i3PEqxYfAR4Ie11trVtRBVVFaLIsevbddsKmqt",
+	"U/rKkkYtWGzqrLu4aK9yWNWoaGVRPBpUcAut9cVPb+7uI5dA1naRva4be8ih4fs6yPXxm/5xrUWDSluH",
+	"834cTlCAUFcYSvSwpGdFgDIqBCdZCupm6ZmrTkVFQmxZn8drluou0YHn73rVcjWFoVNs7mJtpjzMI2dl",
+	"LsXFjCvCPuc0VeRXr74QDoVWStPUUAB/+jUClck1pAaPuWCqXkVUytQUhzTzt1MTMmFtxUDLqZmhO6bm",
+	"zKNPcVv5yczWktu8UuNqdz92ZaG8DGHVQf9L8U5q9tw8j84V0TfAy9CCTqcZm1LNikeebPUxV3Nq8+dU",
+	"GlToYRe9B/xCh0jFRzzTadbQbCsmdM5EUqlP2liyWHKxUpO9wEawwLevT7vx4wmZUTUbknM5Z9XEw0eu",
+	"MmeoAJQic5oUoXsbh8XVf0zmdGmcdkq+sEwaBlldcAOR9Ws5mrmGyN+oPRgoabGcz5nOlqEyoTGf05Q8",
+	"+m53OCKX+Wi0H3+P/2FkdziCExj7XL8iM3kDs1rOsbQYTSvXDWgKFwyGZGRPDJGR0iUpmwfTg+616mfp",
+	"jaAsFXJJ5I0wB/XdjyD0rKpdKtd6ae2MzeW129l4zyNQ+KTytHFJI0yVTZ28Gg81ykSXfFClfHXEJo99",
+	"xVrJE2ly4YSmsUlSmmNS1CBh1+p/FVW/hjLDSTTvR7zlCbxKS85Mea7js9fFufLFD+8/vDA1w0RCqFga",
+	"E6FIygXYjWtOkW4nfJL93/+jzIMIi4wtaMYU4cI8XAkyTMcyNyrVzgk2f+bEOOHpklB3GcPUjzaoYLR/",
+	"iOYasMIKwZXMY8KumdCmZDMubhVhpWVmbNAclTza9O+UmZs7yQZE5vSTeZPru4QtQL8JXdCAUbUcFkRK",
+	"JFNYkXgm04TEGdcoct5Uh+RCGmNBY1M6vzjfAZzME8DsNjKzI2qGRZBhtKWHfsIzFut0iSqJa4w3NxfK",
+	"y055Ptgb7g5HsMRywQRd8MHzwf5whBk7C2rfPt653tuxyhH+DG4BsDqfbUQo1qN3TyrxjGQsNTX6PD07",
+	"JMeuXB9YxCkTLMNG4yUW/pYZmcuMXYpAAWlVAEeigSvhQHlX4Pw1n7O5NEzhfqBLJ8dcwICXwo4YNgtD",
+	"qNiYp9rkTSzolAuHLXoGckIOR1CIOtWwQgB+zAhdLFLOkkuhpV0tBw6tOih288ZxMng++BvTx+YrUj+j",
+	"c6bxpPsfdWq/kPM5JQpEBpFIudJDclxWflbG3kogfcwXnBkdB8LIxQ5KlUccuzyX7qUC6l0Z27SQOAc8",
+	"ccqDaCAoONaeBTQqL5An9zVqKOrTOua3JT/5+FaQ3Xt1tHdwtP/k9OXuk2dHR4cnx/v7e3snT48OTk+e",
+	"vdofjUa7r073n5wcvByd7u0dj06OXr54eXR8eDJ68vT0+OSgZQb6lifroX8slsWjVFQDp/sX/OwCPHrx",
+	"w/Hrd8Pzn9+emDsZlTeIhxfv374/+W735W4bXV0OfH+03pcSRuIaN9ljU4ChXCFcKS7Fo6LweXFuFnll",
+	"s03988imPEX23PpxjYsQhg+ijdIwg7WmZGUS6OmKZYcAu2+lddVZzvyRGi8nz+ktBDUGzw9H0cBGOFqS",
+	"1TqQkpOJYm1YFR/XQasLk18AlFpIoYzjsTcatfkfRbsdq34+2B9gQgOVzyGJy6abgu4FXY+f0DC44uPt",
+	"tuED3hE127qyeanC51JpE4dyYd/MBE2hhdXlYKiHQy3NxnsCunF4Kf5uH4ow4WFRKISbmUzdzpQruHrK",
+	"RJvGLfFfoXTPYZNVbPxhGKvRVXPvX+bbHo2OnjwdPR2NwusOmA86V3k1j70USR+0hsSeKWOIXsibYQ3R",
+	"p0/3Do/aENXyrmh+oOKTOwf1Vnq8NItdZIBV0DTthi1So2RW1XtMgDz8w5bCGESDSdULbtceZT1pj0W1",
+	"tBech+TcuFxjCALpG8YE+cfucLg7Gv0SkcRDeHc0XKF4GirtrkJb4Nsmt64BecNowrKxpFlSCHAi41bJ",
+	"Pb+h0ynLdt4vmABnen84crHc2Ci10m1LZJzPAbVhSMxOZWwyW5uzqw6pWoasjqRqUzy1g1P3xAWdgggP",
+	"zn1kB7+4Oc+K9NlOhUUFMdsmgjslW119xojpT9xsnOAZ57oxd5usu8namq4t62o+ktdiIovVdPfq1lTL",
+	"5pQNNTIVpQRYT5pn9vkMz6W3Akxtqm/R5VJUpDlysSxPqiSKmcTT0Evh3mllIsG4AIRtiL6RZC4Tpp5f",
+	"im8IqHni4t6kUNKEayueioD/gjsvOTG60ARm1ZC8crbhQsL2Dc1Kml4KgjtJ8PNLR7IYA/1/CgCHDgGw",
+	"MwEcKFFcTFOG4wyHF5IoRrN4hp4ey0BPuudl2S1s7gp9rHD63xRAn5OFVIrDdhYDS+o5OZxzEWEubgTp",
+	"qRGWVIgIZkpF5HNOM82yiCwZzYYwo+NUSTLPU80XKXORUjN7qWcsIxN+i9gkeqYikvJPjOyaQQ5mEdlL",
+	"IrJnhjjCMRDoK68PwUgjBDrro5gGNhEN/mYLGc8iWwRCCvJWCsiwjbC0x8ULpKv+8pxI5EeaktfH747N",
+	"2n2R5bODMU0ZLEURSlW2EsPLPJMLtnPCspQLhIZH3s9RLx+MRr+gIcF4cpmTVwAZklMJ+ef20SnCJ+Xi",
+	"ckXmXCmTR/cNOhc7WnqYcqEjY1wVi6VIcCHP3OLlik6de+OdOnxjslJ3TaLxc/LrX93H7xO6hDDd3hHO",
+	"4Pvd0a/15mTMJuCqa9nd0fyl5felOUdY+PKEg0UnwLgwrT7AoN33pRuD4E4NSsYOes6XlTbfHhqGkRPj",
+	"jUXwU1JzQ4DJ3ot06b3hdzAalWtlX04CQWfJc2j9axPpGpphQlyKs9Ifx0CkXTNcLADhUcDpNuE9M++w",
+	"IHBoN7ShdqyHN2OXAidfqgZ3UAG0R1+gyiexFBM+zTNaRjRAx7ipoeYzKsNqFAhygWSULvBz8utfKxN/",
+	"9qyNA6od7RueQt4EQPzquN560GYJvR7CBTOKuaoVzrU7f13hYhciCPxC0zhPDXHW0oT4gBMtFJR5fikX",
+	"XJNHBkgBZyWox1bXHMzqwZCEtm1cvSO30tNbUK1ZBq3/+x+j75798g2g8tvsN0Dkt+S3hC5/Ayx+QyR+",
+	"szj8hij8x6CH9xrWnZ7KHC+d2KHyRQm2ytlOXRVzN8dcMLin71MJIcs5TwSkKUWEgqmhaD/J6fkFsdf3",
+	"IUyGG1hVO3H1MmB9xd22+/+y3t7/XVPDr3be0Uj4qO237H1id6e/3XPffFP2GlyhdGkTtrgyL9BHDS35",
+	"+27W/rCbXeO1GpKVniviyJXnubrXIu8QirzbbsyqvJV7Mtuu4cHHVCQpUzv/hFOvr728eLkAxpnx6Swi",
+	"KRzwiYTEqVTuzbsig6J4y5QL8xSOk5rSxb8UpREss/HwihpQHE3ZhcvKMCZaTuBoJVuam4AuCu9A28pl",
+	"Rk8kJQp44q0iRB6DNTC2wdSepxcuRCWPw+wKzK2rePl9rhKiq/iYGnJRkSpyKdpyRZAGiHVk0YT4vUEV",
+	"oWJjxMmZaeo+y4mBa+ipFUsnSJwiT8frgiCs361nsDDOr3MA4AF/RxGYAz4XZe7FGXqXK6W/2CzPqBL/",
+	"Kg0sAqDFpSgE6wqvtETDXiDT9bTWVhQto9rULq7chshdRuMmDqpnpfS5h3FbY50rFf4Lu+4FN+OyRyY1",
+	"9JHVnY/BE8hVUtGdg1wlLUrBMVMwpGSTNaF3n3jS1pXZujJbV2brytwxuFtRyC2+hHlewLRUDT/CWJN1",
+	"3Ahb1NWmullzZLM0eMyGxhCZGBXJ2EJmhZ3WrJYEV/EutvG+bcRvG/HbRvy2Eb9/24gf3ihfb/8AZuN3",
+	"3j1snfOtc751zrfO+R2dc1/Ztfjm2AT53HjpbdE+RjPBxXT1ab1raPOX3XW64oLd1tfe+tpbX3vra299",
+	"7X97X/ulNQXbw/Wt07t1erdO7+/j9Na0Tovf61q1OryNhx9Wer7lPUsjQ0XNVNDcuK6GpJ5ovJ7YY1lz",
+	"/a70kp1rC12Lm9Zbj3nrMW895q3HvPWY/1095uLS4aYRamNmMDZNnF3ySn0tsAwInzh5bXHAbAB7G7De",
+	"+u5/WN/9X8V133ruvT33uvZrcd2LZuSFYd1WH95URNn5p02m7ZddYjs7+vl1nhQ3pgcVkwGOhWCswn3p",
+	"55KAY24SL025FgvO9EI5hV8zljA2xxIhtayWzgphl+Ink91i00jN+8eY7mqRBIZErzhxkABHUslbDcCO",
+	"LkWxbQFUygreASjoFpnbdpibajCYycQV4uKK3MxMxwIuS+ozvXEifiMzcAEnZMbShHChNKNJBDTKY1PP",
+	"a5HJhcxwfmYPcikMdR2eYkj+hrVcgDB0Cv/UlbKnBjGawDYGVg66PycZoyn/whKCPch4eSmKmYPnWnyX",
+	"gilX1M2MrDRPU8T4d0qBNS/M9XQQXMkNLAej45nPzsaTz9SQvOS42aFmPWSGdyzNItn+tlwIqrhqtioU",
+	"3fh4O5ntTZ8eft6/Hunk8+HRRLDr26Pb+FbHYqbVPM6PDubhw/Iy0f0O5+XWD2qIb/O4vlq44uTdydYF",
+	"2rpA2/DlNnz5R3GCKuq9xQMybciZs/Vt/g/ehFnp9EArZ6XMTZoIK15EpuwuF+HT+23schu73MYut7HL",
+	"bexyG7uEfYn/1E+/sKVz1dcMWg63LvvWZd+67FuX/Y/isnuar8VhP8ciAG1uur5OV0cmywLT7vq1+Qlr",
+	"zkfWJodq4Lek3l5gY1Pz3tTXJ98T8yPUvlfkW7JHvrG/QH12gqnCW0d+68Zv3fitG7914/9N3XjvMaJt",
+	"vu7We956z1vv+WG951LhtDjPAU+17kmvfdBPBcHHxYBeSlPNleZxGbKuHfy7k1JClZIxxyK/aH6ojV24",
+	"U/2yplfLse0p05Snf85T2zschxiyqe7jENuoxhSqPy/UiigX77FXTjLsWsNO4LhoQDNm9IKayRtBpIgZ",
+	"6gpYIPYXRcwTXfY1Rrs45vGZ6opdiokxhza3pKgiV+kJmyFYVxysM0tAHVsMe0bkcDw7QbLImGKieK2k",
+	"cuF93dDbHda+e9Vr1f7tOzybVc627xvhzVX0+3ATOzPv3sqsVKOVJ3+gNViCjI1znmpC7faKaj8rBl+D",
+	"c+7ppQDSZtcMGQf9VfMwj33N6WImM+hgkm6otji42m+CscSybTzj1wZ4Cx/YOZ3a19BW84DyS+W4DB8z",
+	"E4MOV44kNkOnyJSimild36VpOWWop1Bhlqa06mfg01ptlsgMd1cj+WO5+YuKGERorriJKyYsM7eR4xvN",
+	"2CBfn+4ql8Az2A9ugC2LtIiZ/UqQgwoxA25eLWT47g8Q6x0ws33/6hNbWuVndVPQXL7DETaaDvRsmwx8",
+	"q2oMUF07/0QtvNptSIyJ8UtUPi/icbZ44t7BrDhGPz77eUhC0zszCrNTHgE6QYZY+d5OzTirVU+19Eyo",
+	"2uqCP5suAL5srRRR8D7FgHBQhHaAgu2q4bz0tcvH88zJ3wXmPxaNHV0nnKWJfYseg80s8/pCFdjMoJ+A",
+	"CS+j6rgHNk/X/cQV15XkwCJwDe5WkvE0TeRN66NnMNlznNYfWmLhW6xV8WIrEBG6ORJqWbL0gmVcJujv",
+	"DS+FfdCGcEX2R0k9BLB3MGtz+xBKsBrpLvQxPZ9Ai/0R/P8z/P/9o0P4D03TUKXSjfkWl6itBCGsTMl8",
+	"Hay7k7IpjZftDwni54j8tEuUXqYM19tsEcY0/nRDs4QAilTzMU+5Xg7JWcqoYmTOpxnVDNbBZ6qIxDMW",
+	"fzK54napEv+5GMOkRvpWcajB7g/EpxuvpplJv+Ukj653YT5cTB9XlnazzZ+z8mYvphi6MAZcC+1Xagbz",
+	"7KINB6fuzWhby5pj5E7nqkXMio9NMSteGB3gE+FTfERZ5Qrf++xXCHhr6v+8pr5LXdb85G673rmvnqZy",
+	"TFPvlmKZ1YP7gDT1X/sMCpkzvxsczndYhb8ZxAzwYqLmga/hRyXFyvnO8jk1z/LOaTzjwrz9CwJJLJwd",
+	"+3Bt9V2ylgQq6NDrFbKNx23kJUBbeBWWrX6VrHh2eSeWQmkqOhjihW3hyvDmyrxFWz4GHBEly4C1bRZT",
+	"AZEtec2yjCemy5zPeRbUvJm85Sx5USCzUQFj17tNFMwgHuLleE3C2LeAr4rYYSuBXpuWXpQRw3pGQ3lb",
+	"Z/udRHgcuGCZOeGzbwXbj3NUzLbg/wSsMxM6XcIReYn3jKrynI3ii83hvbedssWwiHJuRN46kN5UdvQp",
+	"h29SG1Q2auwuOc04uzbanRUKnouJdG94x5lUCpUQAu0kyZtiwI3uN7revYlQjtecfL8YjHl7wMDFHt7e",
+	"xzwcb0KNHZPePBTjA+g9ZzNcc76fc5azteaLPdaf73/hQJvMF3v2nqgZ5+vXr1//3wDA/KATeD0BAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file